@@ -0,0 +1,297 @@
+package bcs
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var (
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	bigIntType    = reflect.TypeOf(big.Int{})
+)
+
+// Marshal serializes value with bcs.
+//
+// Struct fields are written in declaration order, fields tagged `bcs:"-"` are skipped.
+// Pointers are encoded as Option<T>, slices as vector<T>, arrays as fixed size tuples.
+// *big.Int is written as u128 unless the field is tagged `bcs:"u256"` (or `bcs:"u64"`).
+func Marshal(value interface{}) ([]byte, error) {
+	e := NewEncoder()
+	err := e.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// reflectValue returns an addressable value, a top level pointer is not treated as Option
+func reflectValue(value interface{}) reflect.Value {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return v
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem()
+	}
+	nv := reflect.New(v.Type()).Elem()
+	nv.Set(v)
+	return nv
+}
+
+func (e *Encoder) encode(v reflect.Value, tag string) error {
+	if !v.IsValid() {
+		return fmt.Errorf("bcs: can not encode nil value")
+	}
+	if v.Type().Implements(marshalerType) {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return fmt.Errorf("bcs: can not encode nil %v", v.Type())
+		}
+		return v.Interface().(Marshaler).MarshalBCS(e)
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+		return v.Addr().Interface().(Marshaler).MarshalBCS(e)
+	}
+	if v.Type() == bigIntType {
+		value := v.Addr().Interface().(*big.Int)
+		return e.writeTaggedBigInt(value, tag)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		e.WriteBool(v.Bool())
+	case reflect.Uint8:
+		e.WriteU8(uint8(v.Uint()))
+	case reflect.Uint16:
+		e.WriteU16(uint16(v.Uint()))
+	case reflect.Uint32:
+		e.WriteU32(uint32(v.Uint()))
+	case reflect.Uint64, reflect.Uint:
+		e.WriteU64(v.Uint())
+	case reflect.String:
+		e.WriteString(v.String())
+	case reflect.Ptr:
+		if v.IsNil() {
+			e.WriteU8(0)
+			return nil
+		}
+		if v.Type().Elem() == bigIntType {
+			return e.writeTaggedBigInt(v.Interface().(*big.Int), tag)
+		}
+		e.WriteU8(1)
+		return e.encode(v.Elem(), tag)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.WriteBytes(v.Bytes())
+			return nil
+		}
+		e.WriteUleb128(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			err := e.encode(v.Index(i), tag)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := e.encode(v.Index(i), tag)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldTag := field.Tag.Get("bcs")
+			if field.PkgPath != "" || fieldTag == "-" {
+				continue
+			}
+			err := e.encode(v.Field(i), fieldTag)
+			if err != nil {
+				return fmt.Errorf("%v.%v: %v", t.Name(), field.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("bcs: unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+func (e *Encoder) writeTaggedBigInt(value *big.Int, tag string) error {
+	switch tag {
+	case "u64":
+		if value.Sign() < 0 || !value.IsUint64() {
+			return fmt.Errorf("bcs: %v overflows u64", value)
+		}
+		e.WriteU64(value.Uint64())
+		return nil
+	case "u256":
+		return e.WriteU256(value)
+	default:
+		return e.WriteU128(value)
+	}
+}
+
+// Unmarshal decodes data into value, which must be a pointer, with the same rules as Marshal.
+// All data must be consumed.
+func Unmarshal(data []byte, value interface{}) error {
+	d := NewDecoder(data)
+	err := d.Decode(value)
+	if err != nil {
+		return err
+	}
+	if d.Remaining() != 0 {
+		return fmt.Errorf("bcs: %v bytes left after decoding", d.Remaining())
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+func reflectPointer(value interface{}) reflect.Value {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.Kind() != reflect.Ptr || v.IsNil() {
+		return reflect.Value{}
+	}
+	return v.Elem()
+}
+
+func (d *Decoder) decode(v reflect.Value, tag string) error {
+	if !v.IsValid() || !v.CanSet() {
+		return fmt.Errorf("bcs: decode needs a non nil pointer")
+	}
+	if v.Addr().Type().Implements(unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalBCS(d)
+	}
+	if v.Type() == bigIntType {
+		value, err := d.readTaggedBigInt(tag)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(*value))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		value, err := d.ReadBool()
+		if err != nil {
+			return err
+		}
+		v.SetBool(value)
+	case reflect.Uint8:
+		value, err := d.ReadU8()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(value))
+	case reflect.Uint16:
+		value, err := d.ReadU16()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(value))
+	case reflect.Uint32:
+		value, err := d.ReadU32()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(value))
+	case reflect.Uint64, reflect.Uint:
+		value, err := d.ReadU64()
+		if err != nil {
+			return err
+		}
+		v.SetUint(value)
+	case reflect.String:
+		value, err := d.ReadString()
+		if err != nil {
+			return err
+		}
+		v.SetString(value)
+	case reflect.Ptr:
+		if v.Type().Elem() == bigIntType {
+			value, err := d.readTaggedBigInt(tag)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(value))
+			return nil
+		}
+		flag, err := d.ReadU8()
+		if err != nil {
+			return err
+		}
+		switch flag {
+		case 0:
+			v.Set(reflect.Zero(v.Type()))
+		case 1:
+			elem := reflect.New(v.Type().Elem())
+			err = d.decode(elem.Elem(), tag)
+			if err != nil {
+				return err
+			}
+			v.Set(elem)
+		default:
+			return fmt.Errorf("bcs: invalid option tag %v", flag)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			value, err := d.ReadBytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte{}, value...))
+			return nil
+		}
+		length, err := d.ReadLength()
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), length, length)
+		for i := 0; i < length; i++ {
+			err = d.decode(slice.Index(i), tag)
+			if err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := d.decode(v.Index(i), tag)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldTag := field.Tag.Get("bcs")
+			if field.PkgPath != "" || fieldTag == "-" {
+				continue
+			}
+			err := d.decode(v.Field(i), fieldTag)
+			if err != nil {
+				return fmt.Errorf("%v.%v: %v", t.Name(), field.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("bcs: unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+func (d *Decoder) readTaggedBigInt(tag string) (*big.Int, error) {
+	switch tag {
+	case "u64":
+		value, err := d.ReadU64()
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(value), nil
+	case "u256":
+		return d.ReadU256()
+	default:
+		return d.ReadU128()
+	}
+}
@@ -0,0 +1,85 @@
+package bcs
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+type testStruct struct {
+	Flag    bool
+	Number  uint64
+	Name    string
+	Data    []byte
+	List    []uint16
+	Fixed   [2]uint8
+	Option  *uint32
+	Amount  *big.Int `bcs:"u256"`
+	ignored int
+	Skip    string `bcs:"-"`
+}
+
+func TestMarshal(t *testing.T) {
+	option := uint32(7)
+	value := testStruct{
+		Flag:   true,
+		Number: 1,
+		Name:   "sui",
+		Data:   []byte{1, 2},
+		List:   []uint16{1, 256},
+		Fixed:  [2]uint8{9, 8},
+		Option: &option,
+		Amount: big.NewInt(1),
+		Skip:   "skip",
+	}
+	data, err := Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	expect := "01" + "0100000000000000" + "03737569" + "020102" + "0201000001" + "0908" + "0107000000" +
+		"0100000000000000000000000000000000000000000000000000000000000000"
+	if hex.EncodeToString(data) != expect {
+		t.Fatalf("marshal result %x not match %v", data, expect)
+	}
+}
+
+func TestUleb128(t *testing.T) {
+	for value, expect := range map[uint64]string{0: "00", 127: "7f", 128: "8001", 16384: "808001"} {
+		e := NewEncoder()
+		e.WriteUleb128(value)
+		if hex.EncodeToString(e.Bytes()) != expect {
+			t.Fatalf("uleb128 %v: %x not match %v", value, e.Bytes(), expect)
+		}
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	option := uint32(7)
+	value := testStruct{
+		Flag:   true,
+		Number: 1 << 40,
+		Name:   "sui",
+		Data:   []byte{1, 2},
+		List:   []uint16{1, 256},
+		Fixed:  [2]uint8{9, 8},
+		Option: &option,
+		Amount: new(big.Int).Lsh(big.NewInt(1), 200),
+	}
+	data, err := Marshal(&value)
+	if err != nil {
+		panic(err)
+	}
+	result := testStruct{}
+	err = Unmarshal(data, &result)
+	if err != nil {
+		panic(err)
+	}
+	if result.Number != value.Number || result.Name != value.Name || string(result.Data) != string(value.Data) ||
+		result.List[1] != 256 || result.Fixed != value.Fixed || *result.Option != option || result.Amount.Cmp(value.Amount) != 0 {
+		t.Fatalf("unmarshal result %+v not match %+v", result, value)
+	}
+	err = Unmarshal(append(data, 0), &result)
+	if err == nil {
+		t.Fatalf("expect error for trailing bytes")
+	}
+}
@@ -0,0 +1,151 @@
+package bcs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Unmarshaler is implemented by types with a custom bcs layout
+type Unmarshaler interface {
+	UnmarshalBCS(d *Decoder) error
+}
+
+type Decoder struct {
+	data   []byte
+	offset int
+}
+
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Remaining returns the number of bytes not read yet
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.offset
+}
+
+func (d *Decoder) ReadFixedBytes(n int) ([]byte, error) {
+	if n < 0 || d.Remaining() < n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	data := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return data, nil
+}
+
+func (d *Decoder) ReadBool() (bool, error) {
+	v, err := d.ReadU8()
+	if err != nil {
+		return false, err
+	}
+	switch v {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("bcs: invalid bool %v", v)
+	}
+}
+
+func (d *Decoder) ReadU8() (uint8, error) {
+	data, err := d.ReadFixedBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+func (d *Decoder) ReadU16() (uint16, error) {
+	data, err := d.ReadFixedBytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(data), nil
+}
+
+func (d *Decoder) ReadU32() (uint32, error) {
+	data, err := d.ReadFixedBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+func (d *Decoder) ReadU64() (uint64, error) {
+	data, err := d.ReadFixedBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+func (d *Decoder) ReadU128() (*big.Int, error) {
+	return d.readBigInt(16)
+}
+
+func (d *Decoder) ReadU256() (*big.Int, error) {
+	return d.readBigInt(32)
+}
+
+func (d *Decoder) readBigInt(size int) (*big.Int, error) {
+	data, err := d.ReadFixedBytes(size)
+	if err != nil {
+		return nil, err
+	}
+	reversed := make([]byte, size)
+	for i := range data {
+		reversed[size-1-i] = data[i]
+	}
+	return new(big.Int).SetBytes(reversed), nil
+}
+
+func (d *Decoder) ReadUleb128() (uint64, error) {
+	var value uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := d.ReadU8()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("bcs: uleb128 overflow")
+}
+
+// ReadLength reads a vector length and checks it against the remaining data
+func (d *Decoder) ReadLength() (int, error) {
+	length, err := d.ReadUleb128()
+	if err != nil {
+		return 0, err
+	}
+	if length > uint64(d.Remaining()) {
+		return 0, fmt.Errorf("bcs: length %v exceeds remaining %v bytes", length, d.Remaining())
+	}
+	return int(length), nil
+}
+
+// ReadBytes reads a vector<u8>
+func (d *Decoder) ReadBytes() ([]byte, error) {
+	length, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	return d.ReadFixedBytes(length)
+}
+
+func (d *Decoder) ReadString() (string, error) {
+	data, err := d.ReadBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (d *Decoder) Decode(value interface{}) error {
+	return d.decode(reflectPointer(value), "")
+}
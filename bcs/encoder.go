@@ -0,0 +1,106 @@
+package bcs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// Marshaler is implemented by types with a custom bcs layout (enums, fixed size ids ...)
+type Marshaler interface {
+	MarshalBCS(e *Encoder) error
+}
+
+type Encoder struct {
+	buf bytes.Buffer
+}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+func (e *Encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+func (e *Encoder) WriteBool(v bool) {
+	if v {
+		e.buf.WriteByte(1)
+	} else {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *Encoder) WriteU8(v uint8) {
+	e.buf.WriteByte(v)
+}
+
+func (e *Encoder) WriteU16(v uint16) {
+	var data [2]byte
+	binary.LittleEndian.PutUint16(data[:], v)
+	e.buf.Write(data[:])
+}
+
+func (e *Encoder) WriteU32(v uint32) {
+	var data [4]byte
+	binary.LittleEndian.PutUint32(data[:], v)
+	e.buf.Write(data[:])
+}
+
+func (e *Encoder) WriteU64(v uint64) {
+	var data [8]byte
+	binary.LittleEndian.PutUint64(data[:], v)
+	e.buf.Write(data[:])
+}
+
+func (e *Encoder) WriteU128(v *big.Int) error {
+	return e.writeBigInt(v, 16)
+}
+
+func (e *Encoder) WriteU256(v *big.Int) error {
+	return e.writeBigInt(v, 32)
+}
+
+func (e *Encoder) writeBigInt(v *big.Int, size int) error {
+	if v == nil {
+		v = big.NewInt(0)
+	}
+	if v.Sign() < 0 || v.BitLen() > size*8 {
+		return fmt.Errorf("bcs: %v overflows u%v", v, size*8)
+	}
+	data := make([]byte, size)
+	v.FillBytes(data)
+	for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	e.buf.Write(data)
+	return nil
+}
+
+func (e *Encoder) WriteUleb128(v uint64) {
+	for v >= 0x80 {
+		e.buf.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	e.buf.WriteByte(byte(v))
+}
+
+// WriteFixedBytes writes data without length prefix
+func (e *Encoder) WriteFixedBytes(data []byte) {
+	e.buf.Write(data)
+}
+
+// WriteBytes writes data as vector<u8>
+func (e *Encoder) WriteBytes(data []byte) {
+	e.WriteUleb128(uint64(len(data)))
+	e.buf.Write(data)
+}
+
+func (e *Encoder) WriteString(v string) {
+	e.WriteBytes([]byte(v))
+}
+
+func (e *Encoder) Encode(value interface{}) error {
+	return e.encode(reflectValue(value), "")
+}
@@ -2,35 +2,57 @@ package go_sui_sdk
 
 import (
 	"bytes"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/ltp456/go-sui-sdk/crypto"
 	"github.com/ltp456/go-sui-sdk/types"
-	"golang.org/x/crypto/blake2b"
 	"io/ioutil"
 	"log"
 	"math/big"
 	"net/http"
 	"reflect"
 	"strconv"
+	"sync"
+	"time"
 )
 
 type SuiClient struct {
-	imp      *http.Client
-	endpoint string
-	debug    bool
+	imp         *http.Client
+	endpoint    string
+	debug       bool
+	concurrency int
+
+	metadataLock sync.RWMutex
+	coinMetadata map[types.CoinType]*types.CoinMetadata
+
+	reserveLock sync.Mutex
+	reserved    map[string]bool
+
+	gasPriceLock   sync.Mutex
+	gasPrice       *big.Int
+	gasPriceExpiry time.Time
 }
 
 func NewSuiClient(endpoint string) (*SuiClient, error) {
 	client := &SuiClient{
-		endpoint: endpoint,
-		imp:      http.DefaultClient,
-		debug:    false,
+		endpoint:     endpoint,
+		imp:          http.DefaultClient,
+		debug:        false,
+		concurrency:  defaultConcurrency,
+		coinMetadata: map[types.CoinType]*types.CoinMetadata{},
+		reserved:     map[string]bool{},
 	}
 	return client, nil
 }
 
+// SetConcurrency caps the number of requests batch apis run at the same time
+func (si *SuiClient) SetConcurrency(concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	si.concurrency = concurrency
+}
+
 func (si *SuiClient) GetLatestCheckpointSequenceNumber() (uint64, error) {
 	var result string
 	err := si.post("sui_getLatestCheckpointSequenceNumber", nil, &result)
@@ -79,12 +101,11 @@ func (si *SuiClient) GetEvents(digest string) ([]types.TxEvent, error) {
 	return result, err
 }
 
-func (si *SuiClient) GetTransactionBlock(digest string) (*types.TransactionBlock, error) {
+func (si *SuiClient) GetTransactionBlock(digest string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
 	result := &types.TransactionBlock{}
-	childParam := si.getDefaultTxOption()
 	params := Params{}
 	params.AddValue(digest)
-	params.AddValue(childParam)
+	params.AddValue(txOptions(options))
 	err := si.post("sui_getTransactionBlock", params, result)
 	if err != nil {
 		return nil, err
@@ -99,7 +120,7 @@ func (si *SuiClient) TransactionsV1(checkpoint uint64) ([]types.Tx, error) {
 	}
 	var result []types.Tx
 	for _, digest := range checkPoints.Transactions {
-		transaction, err := si.GetTransactionBlock(digest)
+		transaction, err := si.GetTransactionBlock(digest, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -119,19 +140,21 @@ func (si *SuiClient) Transactions(start, limit uint64) ([]types.Tx, uint64, erro
 		return nil, 0, err
 	}
 
-	var result []types.Tx
+	var digests []string
 	for _, checkPoint := range checkPoints.Data {
-		for _, tx := range checkPoint.Transactions {
-			transaction, err := si.GetTransactionBlock(tx)
-			if err != nil {
-				return nil, 0, err
-			}
-			txList, err := transaction.Parse()
-			if err != nil {
-				continue
-			}
-			result = append(result, txList...)
+		digests = append(digests, checkPoint.Transactions...)
+	}
+	transactions := si.MultiGetTransactionBlocks(digests, nil)
+	var result []types.Tx
+	for _, transaction := range transactions {
+		if transaction.Err != nil {
+			return nil, 0, transaction.Err
 		}
+		txList, err := transaction.Block.Parse()
+		if err != nil {
+			continue
+		}
+		result = append(result, txList...)
 	}
 	return result, uint64(len(checkPoints.Data)), err
 }
@@ -218,7 +241,7 @@ func (si *SuiClient) GetAllObjectId(address string) ([]string, error) {
 	var ownedObjects *types.ObjectInfo
 	var err error
 	var hasNetxPage bool
-	ownedObjects, err = si.GetOwnedObjects("", address)
+	ownedObjects, err = si.GetOwnedObjects("", address, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -228,7 +251,7 @@ func (si *SuiClient) GetAllObjectId(address string) ([]string, error) {
 
 	hasNetxPage = ownedObjects.HasNextPage
 	for hasNetxPage {
-		ownedObjects, err = si.GetOwnedObjects(ownedObjects.NextCursor, address)
+		ownedObjects, err = si.GetOwnedObjects(ownedObjects.NextCursor, address, nil, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -285,23 +308,65 @@ func (si *SuiClient) GetCoins(coinType types.CoinType, address, cursor string) (
 	return result, err
 }
 
-func (si *SuiClient) GetOwnedObjects(cursor, address string) (*types.ObjectInfo, error) {
+// GetOwnedObjects returns one page, a nil query returns every object with the node default options
+func (si *SuiClient) GetOwnedObjects(cursor, address string, query *types.ObjectResponseQuery, limit uint64) (*types.ObjectInfo, error) {
 	result := &types.ObjectInfo{}
 	params := Params{}
 	params.AddValue(address)
-	if cursor != "" {
-		params.AddValue(cursor)
-	}
+	params.AddValue(query)
+	params.AddValue(cursorParam(cursor))
+	params.AddValue(limitParam(limit))
 	err := si.post("suix_getOwnedObjects", params, result)
 	return result, err
 }
 
-func (si *SuiClient) GetObject(objId string) (*types.ObjData, error) {
+// GetObject returns ObjectNotExistsError or ObjectDeletedError when the object is gone,
+// a display error is left in the result next to the object
+func (si *SuiClient) GetObject(objId string, options *types.ObjectDataOptions) (*types.ObjData, error) {
 	result := &types.ObjData{}
 	params := Params{}
 	params.AddValue(objId)
+	params.AddValue(objectOptions(options))
 	err := si.post("sui_getObject", params, result)
-	return result, err
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil && result.Error.Code != types.DisplayErrorCode {
+		return nil, result.Error.Err()
+	}
+	return result, nil
+}
+
+// ReadObject decodes the move fields of an object into value, see types.DecodeMoveFields
+func (si *SuiClient) ReadObject(objId string, value interface{}) error {
+	object, err := si.GetObject(objId, &types.ObjectDataOptions{ShowType: true, ShowContent: true})
+	if err != nil {
+		return err
+	}
+	return object.Data.DecodeFields(value)
+}
+
+// ReadObjectBcs decodes the bcs bytes of an object into value, see types.Object.DecodeBcs
+func (si *SuiClient) ReadObjectBcs(objId string, value interface{}) error {
+	object, err := si.GetObject(objId, &types.ObjectDataOptions{ShowType: true, ShowBcs: true})
+	if err != nil {
+		return err
+	}
+	return object.Data.DecodeBcs(value)
+}
+
+// TryGetPastObject returns ObjectVersionNotFoundError or ObjectVersionTooHighError when the version is not available
+func (si *SuiClient) TryGetPastObject(objId string, version uint64, options *types.ObjectDataOptions) (*types.Object, error) {
+	result := &types.PastObject{}
+	params := Params{}
+	params.AddValue(objId)
+	params.AddValue(version)
+	params.AddValue(objectOptions(options))
+	err := si.post("sui_tryGetPastObject", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Object(objId, version)
 }
 
 func (si *SuiClient) DryRunTransactionBlock(coinType types.CoinType, payAllSui bool, sender string, objectIds []string, recipient string, amount string, gasBudget string) (*types.TransactionBlock, error) {
@@ -309,19 +374,19 @@ func (si *SuiClient) DryRunTransactionBlock(coinType types.CoinType, payAllSui b
 	var err error
 	if coinType == types.SuiCoinType {
 		if payAllSui {
-			unsignedTx, err = si.payAllSui(sender, recipient, objectIds, gasBudget)
+			unsignedTx, err = si.BuildPayAllSui(sender, recipient, objectIds, gasBudget)
 			if err != nil {
 				return nil, err
 			}
 		} else {
-			unsignedTx, err = si.paySui(sender, objectIds, []string{recipient}, []string{amount}, gasBudget)
+			unsignedTx, err = si.BuildPaySui(sender, objectIds, []string{recipient}, []string{amount}, gasBudget)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 	} else {
-		unsignedTx, err = si.pay(sender, "", nil, []string{recipient}, []string{amount}, gasBudget)
+		unsignedTx, err = si.BuildPay(sender, "", nil, []string{recipient}, []string{amount}, gasBudget)
 		if err != nil {
 			return nil, err
 		}
@@ -339,19 +404,19 @@ func (si *SuiClient) DevInspectTransactionBlock(coinType types.CoinType, payAllS
 	var err error
 	if coinType == types.SuiCoinType {
 		if payAllSui {
-			unsignedTx, err = si.payAllSui(sender, recipient, objectIds, gasBudget)
+			unsignedTx, err = si.BuildPayAllSui(sender, recipient, objectIds, gasBudget)
 			if err != nil {
 				return nil, err
 			}
 		} else {
-			unsignedTx, err = si.paySui(sender, objectIds, []string{recipient}, []string{amount}, gasBudget)
+			unsignedTx, err = si.BuildPaySui(sender, objectIds, []string{recipient}, []string{amount}, gasBudget)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 	} else {
-		unsignedTx, err = si.pay(sender, "", nil, []string{recipient}, []string{amount}, gasBudget)
+		unsignedTx, err = si.BuildPay(sender, "", nil, []string{recipient}, []string{amount}, gasBudget)
 		if err != nil {
 			return nil, err
 		}
@@ -364,157 +429,147 @@ func (si *SuiClient) DevInspectTransactionBlock(coinType types.CoinType, payAllS
 
 }
 
-func (si *SuiClient) Transfer(coinType types.CoinType, seed []byte, sender string, allObjectIds, recipient []string, amount []string, gasBudget string) (*types.TransactionBlock, error) {
+func (si *SuiClient) Transfer(coinType types.CoinType, seed []byte, sender string, allObjectIds, recipient []string, amount []string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
 	var unsignedTx *types.UnsignedTx
 	var err error
 	if coinType == types.SuiCoinType {
-		unsignedTx, err = si.paySui(sender, allObjectIds, recipient, amount, gasBudget)
+		unsignedTx, err = si.BuildPaySui(sender, allObjectIds, recipient, amount, gasBudget)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		unsignedTx, err = si.pay(sender, "", nil, recipient, amount, gasBudget)
+		unsignedTx, err = si.BuildPay(sender, "", nil, recipient, amount, gasBudget)
 		if err != nil {
 			return nil, err
 		}
 	}
-	result, err := si.signAndSubmitTx(seed, unsignedTx)
+	result, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (si *SuiClient) Pay(seed []byte, sender, gasObjectId string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.pay(sender, gasObjectId, inputCoins, recipient, amount, gasBudget)
+func (si *SuiClient) Pay(seed []byte, sender, gasObjectId string, inputCoins, recipient []string, amount []string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildPay(sender, gasObjectId, inputCoins, recipient, amount, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) PayAllSui(seed []byte, sender, recipient string, suiObjectId []string, gasBudget string) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.payAllSui(sender, recipient, suiObjectId, gasBudget)
+func (si *SuiClient) PayAllSui(seed []byte, sender, recipient string, suiObjectId []string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildPayAllSui(sender, recipient, suiObjectId, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) PaySui(seed []byte, sender string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.paySui(sender, inputCoins, recipient, amount, gasBudget)
+func (si *SuiClient) PaySui(seed []byte, sender string, inputCoins, recipient []string, amount []string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildPaySui(sender, inputCoins, recipient, amount, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) TransferSui(seed []byte, sender, recipient, suiObjectId string, amount string, gasBudget string) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.transferSui(sender, recipient, suiObjectId, amount, gasBudget)
+func (si *SuiClient) TransferSui(seed []byte, sender, recipient, suiObjectId string, amount string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildTransferSui(sender, recipient, suiObjectId, amount, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) TransferObject(seed []byte, sender, recipient, suiObjectId, gasObjectId string, gasBudget string) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.transferObject(sender, recipient, suiObjectId, gasObjectId, gasBudget)
+func (si *SuiClient) TransferObject(seed []byte, sender, recipient, suiObjectId, gasObjectId string, gasBudget string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildTransferObject(sender, recipient, suiObjectId, gasObjectId, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) MoveCall(seed []byte, sender, packageObjectId, module, function, gasObjectId string, typeArguments, arguments []string, gasBudget uint64) (*types.TransactionBlock, error) {
-	unsignedTx, err := si.moveCall(sender, packageObjectId, module, function, gasObjectId, typeArguments, arguments, gasBudget)
+func (si *SuiClient) MoveCall(seed []byte, sender, packageObjectId, module, function, gasObjectId string, typeArguments, arguments []string, gasBudget uint64, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	unsignedTx, err := si.BuildMoveCall(sender, packageObjectId, module, function, gasObjectId, typeArguments, arguments, gasBudget)
 	if err != nil {
 		return nil, err
 	}
-	submitTx, err := si.signAndSubmitTx(seed, unsignedTx)
+	submitTx, err := si.signAndSubmitTx(seed, unsignedTx, options)
 	if err != nil {
 		return nil, err
 	}
 	return submitTx, nil
 }
 
-func (si *SuiClient) signAndSubmitTx(seed []byte, unsignedTx *types.UnsignedTx) (*types.TransactionBlock, error) {
+func (si *SuiClient) signAndSubmitTx(seed []byte, unsignedTx *types.UnsignedTx, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
 	keyPair, err := crypto.NewKeyPairFromSeed(seed)
 	if err != nil {
 		return nil, err
 	}
-	txBytes, err := base64.StdEncoding.DecodeString(unsignedTx.TxBytes)
+	return si.SignAndSubmitTx(keyPair, unsignedTx, options)
+}
+
+// SignAndSubmitTx signs unsignedTx after CheckExpiration, so an expired transaction is not signed
+func (si *SuiClient) SignAndSubmitTx(signer crypto.Signer, unsignedTx *types.UnsignedTx, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	err := si.CheckExpiration(unsignedTx.TxBytes)
 	if err != nil {
 		return nil, err
 	}
-
-	txData := make([]byte, 0)
-	txData = append(txData, types.IntentFlag...)
-	txData = append(txData, txBytes...)
-	txHash := blake2b.Sum256(txData)
-	signature, err := keyPair.Sign(txHash[:])
+	base64Signature, err := SignTransaction(signer, unsignedTx.TxBytes)
 	if err != nil {
 		return nil, err
 	}
+	return si.ExecuteTransactionBlock(unsignedTx.TxBytes, []string{base64Signature}, types.WaitForLocalExecution.String(), options)
+}
 
-	signatureData := make([]byte, 0)
-	signatureData = append(signatureData, []byte{0}...)
-	signatureData = append(signatureData, signature...)
-	signatureData = append(signatureData, keyPair.PublicKey...)
-
-	base64Signature := base64.StdEncoding.EncodeToString(signatureData)
+// SubmitSignedTransaction submits signatures gathered elsewhere, e.g. offline or from a sponsor,
+// after CheckSignatures and CheckExpiration. Use ExecuteTransactionBlock to skip the checks.
+func (si *SuiClient) SubmitSignedTransaction(txBytes string, signatures []string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	err := CheckSignatures(txBytes, signatures)
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := si.ExecuteTransactionBlock(unsignedTx.TxBytes, base64Signature, types.WaitForLocalExecution.String(), si.getDefaultTxOption())
+	err = si.CheckExpiration(txBytes)
 	if err != nil {
 		return nil, err
 	}
-	return transaction, nil
-}
-
-func (si *SuiClient) getDefaultTxOption() MapParams {
-	params := MapParams{}
-	params.SetKey("showInput", true)
-	params.SetKey("showRawInput", false)
-	params.SetKey("showEffects", true)
-	params.SetKey("showEvents", false)
-	params.SetKey("showObjectChanges", false)
-	params.SetKey("showBalanceChanges", true)
-	return params
+	return si.ExecuteTransactionBlock(txBytes, signatures, types.WaitForLocalExecution.String(), options)
 }
 
-func (si *SuiClient) ExecuteTransactionBlock(txBytes, signature, requestType string, options MapParams) (*types.TransactionBlock, error) {
+// ExecuteTransactionBlock submits txBytes with the serialized signatures of every signer, e.g. sender and sponsor
+func (si *SuiClient) ExecuteTransactionBlock(txBytes string, signatures []string, requestType string, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
 	result := &types.TransactionBlock{}
 	params := Params{}
 	params.AddValue(txBytes)
-	params.AddValue([]string{signature})
-	params.AddValue(options)
+	params.AddValue(signatures)
+	params.AddValue(txOptions(options))
 	params.AddValue(requestType)
 	err := si.post("sui_executeTransactionBlock", params, result)
 	return result, err
 }
 
-func (si *SuiClient) pay(sender, gasObjectId string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildPay(sender, gasObjectId string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -527,7 +582,7 @@ func (si *SuiClient) pay(sender, gasObjectId string, inputCoins, recipient []str
 	return result, err
 }
 
-func (si *SuiClient) paySui(sender string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildPaySui(sender string, inputCoins, recipient []string, amount []string, gasBudget string) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -539,7 +594,7 @@ func (si *SuiClient) paySui(sender string, inputCoins, recipient []string, amoun
 	return result, err
 }
 
-func (si *SuiClient) payAllSui(sender, recipient string, inputCoins []string, gasBudget string) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildPayAllSui(sender, recipient string, inputCoins []string, gasBudget string) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -550,7 +605,7 @@ func (si *SuiClient) payAllSui(sender, recipient string, inputCoins []string, ga
 	return result, err
 }
 
-func (si *SuiClient) transferObject(sender, recipient, objectId, gasObjectId string, gasBudget string) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildTransferObject(sender, recipient, objectId, gasObjectId string, gasBudget string) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -562,7 +617,7 @@ func (si *SuiClient) transferObject(sender, recipient, objectId, gasObjectId str
 	return result, err
 }
 
-func (si *SuiClient) transferSui(sender, recipient, suiObjectId string, amount, gasBudget string) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildTransferSui(sender, recipient, suiObjectId string, amount, gasBudget string) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -574,7 +629,7 @@ func (si *SuiClient) transferSui(sender, recipient, suiObjectId string, amount,
 	return result, err
 }
 
-func (si *SuiClient) moveCall(sender, packageObjectId, module, function, gasObjectId string, typeArguments, arguments []string, gasBudget uint64) (*types.UnsignedTx, error) {
+func (si *SuiClient) BuildMoveCall(sender, packageObjectId, module, function, gasObjectId string, typeArguments, arguments []string, gasBudget uint64) (*types.UnsignedTx, error) {
 	result := &types.UnsignedTx{}
 	params := Params{}
 	params.AddValue(sender)
@@ -667,7 +722,7 @@ func (si *SuiClient) httpReq(httpMethod, method string, param Params, value inte
 		return fmt.Errorf("%v jsonRpc reqId  %v not match RespId %v", method, id, jsonResp.Id)
 	}
 	if jsonResp.Error.Code != 0 {
-		return fmt.Errorf("jsonRpc error: %v", jsonResp.Error.Message)
+		return &jsonResp.Error
 	}
 
 	err = Unmarshal(jsonResp.Result, value)
@@ -1,13 +1,24 @@
 package go_sui_sdk
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
 	"github.com/ltp456/go-sui-sdk/crypto"
 	"github.com/ltp456/go-sui-sdk/types"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var client *SuiClient
@@ -60,6 +71,17 @@ func TestSuiClient_GetEvents(t *testing.T) {
 	fmt.Println(events)
 }
 
+func TestSuiClient_QueryEvents(t *testing.T) {
+	filter := types.MoveEventModuleFilter("0x3", "validator")
+	page, err := client.QueryEvents(filter, nil, 10, true)
+	if err != nil {
+		panic(err)
+	}
+	for _, event := range page.Data {
+		fmt.Println(event.Type, event.ID.TxDigest)
+	}
+}
+
 func TestSuiClient_GetReferenceGasPrice(t *testing.T) {
 	price, err := client.GetReferenceGasPrice()
 	if err != nil {
@@ -68,8 +90,20 @@ func TestSuiClient_GetReferenceGasPrice(t *testing.T) {
 	fmt.Println(price)
 }
 
+func TestSuiClient_GasPrice(t *testing.T) {
+	price, err := client.GasPrice(1.5)
+	if err != nil {
+		panic(err)
+	}
+	payment, err := client.SelectGasPayment("0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e", 5000000, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(price, payment)
+}
+
 func TestSuiClient_GetObject(t *testing.T) {
-	object, err := client.GetObject("0x5994bda6a98e5b8f29717bb066cf2b309344c1aa6cf247ed8ab90e244857394b")
+	object, err := client.GetObject("0x5994bda6a98e5b8f29717bb066cf2b309344c1aa6cf247ed8ab90e244857394b", types.FullObjectDataOptions())
 	if err != nil {
 		panic(err)
 	}
@@ -77,7 +111,7 @@ func TestSuiClient_GetObject(t *testing.T) {
 }
 
 func TestSuiClient_GetOwnedObjects(t *testing.T) {
-	objects, err := client.GetOwnedObjects("", "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e")
+	objects, err := client.GetOwnedObjects("", "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e", nil, 0)
 	if err != nil {
 		panic(err)
 	}
@@ -86,6 +120,186 @@ func TestSuiClient_GetOwnedObjects(t *testing.T) {
 	}
 }
 
+func TestSuiClient_OwnedObjectsOfType(t *testing.T) {
+	it := client.NewOwnedObjectsOfTypeIterator("0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e", types.SuiCoinResType.String(), nil)
+	for it.Next() {
+		fmt.Println(it.Value().ObjectID, it.Value().Type)
+	}
+	if it.Err() != nil {
+		panic(it.Err())
+	}
+}
+
+func TestSuiClient_DynamicFields(t *testing.T) {
+	it := client.NewDynamicFieldIterator("0x5", 0)
+	for it.Next() {
+		field := it.Value()
+		fmt.Println(field.Name.Type, field.Name.Value, field.ObjectType)
+		object, err := client.GetDynamicFieldObject("0x5", field.Name)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(object.Data.ObjectID)
+	}
+	if it.Err() != nil {
+		panic(it.Err())
+	}
+}
+
+func TestSuiClient_CoinMetadata(t *testing.T) {
+	metadata, err := client.CoinMetadata(types.SuiCoinType)
+	if err != nil {
+		panic(err)
+	}
+	supply, err := client.GetTotalSupply(types.SuiCoinType)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(metadata.Symbol, metadata.Decimals, metadata.Amount(supply))
+}
+
+func TestSuiClient_CoinIterator(t *testing.T) {
+	it := client.NewCoinIterator("0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e", "", 0)
+	for it.Next() {
+		coin := it.Value()
+		fmt.Println(coin.CoinType, coin.CoinObjectID, coin.Balance, coin.Version, coin.PreviousTransaction)
+	}
+	if it.Err() != nil {
+		panic(it.Err())
+	}
+}
+
+func TestSelectCoins(t *testing.T) {
+	var coins []types.CoinData
+	for i, balance := range []string{"5", "50", "20", "1", "30"} {
+		coins = append(coins, types.CoinData{CoinType: types.SuiCoinType.String(), CoinObjectID: fmt.Sprintf("0x%v", i+1), Balance: balance})
+	}
+	coins = append(coins, types.CoinData{CoinType: types.SuiCoinType.String(), CoinObjectID: "0x9", Balance: "100", LockedUntilEpoch: 10})
+	cases := []struct {
+		strategy  CoinSelectStrategy
+		amount    int64
+		maxInputs int
+		expect    []string
+	}{
+		{LargestFirst, 60, 0, []string{"0x2", "0x5"}},
+		{SmallestFirst, 25, 0, []string{"0x4", "0x1", "0x3"}},
+		{SmallestFirst, 60, 2, []string{"0x5", "0x2"}},
+		{MinimizeInputs, 60, 0, []string{"0x2", "0x3"}},
+		{ExactMatch, 20, 0, []string{"0x3"}},
+		{ExactMatch, 21, 0, []string{"0x5"}},
+	}
+	for _, item := range cases {
+		selected, err := SelectCoins(coins, big.NewInt(item.amount), &CoinSelectOptions{Strategy: item.strategy, MaxInputs: item.maxInputs})
+		if err != nil {
+			panic(err)
+		}
+		if fmt.Sprint(CoinObjectIds(selected)) != fmt.Sprint(item.expect) {
+			t.Fatalf("strategy %v amount %v: got %v, expect %v", item.strategy, item.amount, CoinObjectIds(selected), item.expect)
+		}
+	}
+	_, err := SelectCoins(coins, big.NewInt(90), &CoinSelectOptions{MaxInputs: 2})
+	insufficient, ok := err.(*types.InsufficientBalanceError)
+	if !ok || insufficient.Available.Int64() != 80 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// testTxData merges the owned coin 0x5 into the gas coin, paid with the gas object 0x6 of gasOwner
+func testTxData(sender, gasOwner string) *types.TransactionData {
+	builder := types.NewProgrammableTransactionBuilder()
+	builder.MergeCoins(types.GasCoinArgument(), []types.Argument{builder.OwnedObject(types.ObjectRef{ObjectID: "0x5", Version: 7, Digest: "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf"})})
+	return types.NewTransactionData(sender, builder.Finish(), types.GasData{
+		Payment: []types.Payment{{ObjectID: "0x6", Version: 3, Digest: "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf"}},
+		Owner:   gasOwner,
+		Price:   "750",
+		Budget:  "5000000",
+	})
+}
+
+func TestSponsoredTransaction(t *testing.T) {
+	sender, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	sponsor, err := crypto.NewKeyPairFromSeed(append(make([]byte, crypto.SeedLength-1), 1))
+	if err != nil {
+		panic(err)
+	}
+	txBytes, err := testTxData(sender.Address(), sponsor.Address()).TxBytes()
+	if err != nil {
+		panic(err)
+	}
+	st := &SponsoredTransaction{TxBytes: txBytes, Sender: sender.Address(), Sponsor: sponsor.Address()}
+	if err := st.SignAsSponsor(sender); err == nil {
+		t.Fatalf("sender accepted as sponsor")
+	}
+	if _, err := st.Signatures(); err == nil {
+		t.Fatalf("unsigned transaction accepted")
+	}
+	if err := st.SignAsSender(sender); err != nil {
+		panic(err)
+	}
+	if err := st.SignAsSponsor(sponsor); err != nil {
+		panic(err)
+	}
+	signatures, err := st.Signatures()
+	if err != nil {
+		panic(err)
+	}
+	if err := CheckSignatures(st.TxBytes, signatures); err != nil {
+		t.Fatalf("check signatures error: %v", err)
+	}
+	if err := CheckSignatures(st.TxBytes, signatures[:1]); err == nil {
+		t.Fatalf("missing sponsor signature accepted")
+	}
+	if err := CheckSignatures(st.TxBytes, []string{signatures[0], signatures[0]}); err == nil {
+		t.Fatalf("duplicate sender signature accepted")
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	sender, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	txData := testTxData(sender.Address(), sender.Address())
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		panic(err)
+	}
+	summary, err := NewTransactionSummary(txData)
+	if err != nil {
+		panic(err)
+	}
+	envelope := &Envelope{Version: EnvelopeVersion, ChainIdentifier: "35834a8a", TxBytes: txBytes, Summary: summary, Signers: txData.Signers()}
+	data, err := envelope.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	offline, err := ParseEnvelope(data)
+	if err != nil {
+		panic(err)
+	}
+	if err := Sign(offline, sender); err != nil {
+		panic(err)
+	}
+	if err := CheckSignatures(offline.TxBytes, []string{offline.Signatures[offline.Signers[0]]}); err != nil {
+		t.Fatalf("check signatures error: %v", err)
+	}
+	offline.Summary.GasBudget = "1"
+	if err := Sign(offline, sender); err == nil {
+		t.Fatalf("tampered envelope signed")
+	}
+}
+
+func TestSuiClient_CurrentEpoch(t *testing.T) {
+	epoch, err := client.CurrentEpoch()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(epoch)
+}
+
 func TestSuiClient_GetBalance(t *testing.T) {
 	balance, err := client.GetBalance(types.SuiCoinType, "0xc0ee0c49b3be532975fdb2c02a3ae8dea70b58f53879f70ba256974627e23ee3")
 	if err != nil {
@@ -103,7 +317,7 @@ func TestSuiClient_GetAllBalances(t *testing.T) {
 }
 
 func TestSuiClient_GetTx(t *testing.T) {
-	transactionBlock, err := client.GetTransactionBlock("HrEB6m8Qv2mrHqs6G82r8wsXacGTV4cmppJCmFi4PTmc")
+	transactionBlock, err := client.GetTransactionBlock("HrEB6m8Qv2mrHqs6G82r8wsXacGTV4cmppJCmFi4PTmc", types.FullTransactionBlockResponseOptions())
 	if err != nil {
 		panic(err)
 	}
@@ -128,7 +342,7 @@ func TestSuiClient_GetTransactionBlock(t *testing.T) {
 		"65DUE8n3uMH8RHeb7RfHFFsVatXV4Tcmrsu87aH11rju",
 	}
 	for _, digest := range digestList {
-		transactionBlock, err := client.GetTransactionBlock(digest)
+		transactionBlock, err := client.GetTransactionBlock(digest, nil)
 		if err != nil {
 			panic(err)
 		}
@@ -143,6 +357,258 @@ func TestSuiClient_GetTransactionBlock(t *testing.T) {
 
 }
 
+func TestSuiClient_MultiGetTransactionBlocks(t *testing.T) {
+	digestList := []string{
+		"6RdJ69bh7LKWUa8upf1gRxjCApDhgvJ9S2pXDmdUHPeW",
+		"3QixuaFW8BJWK1Sg2H7z69p3yF9tp28k7ysAd8Wg6UNw",
+		"5KxLweSkBg3uavjjyMNuTdwEP42Kqhyjm6KnCciKpWs6",
+	}
+	results := client.MultiGetTransactionBlocks(digestList, nil)
+	for _, result := range results {
+		if result.Err != nil {
+			panic(result.Err)
+		}
+		fmt.Println(result.Digest, result.Block.Status())
+	}
+}
+
+func TestSuiClient_MultiGetObjects(t *testing.T) {
+	results := client.MultiGetObjects([]string{"0x5994bda6a98e5b8f29717bb066cf2b309344c1aa6cf247ed8ab90e244857394b", "0x6"}, nil)
+	for _, result := range results {
+		fmt.Println(result.ObjectID, result.Object, result.Err)
+	}
+}
+
+// newTestNode serves json rpc calls with handle, handle returns the result or the rpc error
+func newTestNode(t *testing.T, handle func(method string, params []json.RawMessage) (interface{}, *Error)) *SuiClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		result, rpcErr := handle(req.Method, req.Params)
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	testClient, err := NewSuiClient(server.URL)
+	if err != nil {
+		panic(err)
+	}
+	return testClient
+}
+
+func testDigests(count int) []string {
+	var digests []string
+	for i := 0; i < count; i++ {
+		digests = append(digests, fmt.Sprintf("digest-%03d", i))
+	}
+	return digests
+}
+
+func TestMultiGetTransactionBlocks_Chunks(t *testing.T) {
+	var lock sync.Mutex
+	var chunkSizes []int
+	single := 0
+	missing := "digest-077"
+	notFound := &Error{Code: -32602, Message: "Could not find the referenced transaction [TransactionDigest(" + missing + ")]."}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		lock.Lock()
+		defer lock.Unlock()
+		switch method {
+		case "sui_multiGetTransactionBlocks":
+			var digests []string
+			json.Unmarshal(params[0], &digests)
+			chunkSizes = append(chunkSizes, len(digests))
+			var blocks []types.TransactionBlock
+			// answer in reverse order, results must follow the input order anyway
+			for i := len(digests) - 1; i >= 0; i-- {
+				if digests[i] == missing {
+					return nil, notFound
+				}
+				blocks = append(blocks, types.TransactionBlock{Digest: digests[i]})
+			}
+			return blocks, nil
+		case "sui_getTransactionBlock":
+			single++
+			var digest string
+			json.Unmarshal(params[0], &digest)
+			if digest == missing {
+				return nil, notFound
+			}
+			return types.TransactionBlock{Digest: digest}, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	testClient.SetConcurrency(2)
+
+	digests := testDigests(120)
+	results := testClient.MultiGetTransactionBlocks(digests, nil)
+	sort.Ints(chunkSizes)
+	if fmt.Sprint(chunkSizes) != "[20 50 50]" {
+		t.Fatalf("unexpected chunks: %v", chunkSizes)
+	}
+	// only the chunk holding the missing digest is fetched one by one
+	if single != MaxMultiGetLimit {
+		t.Fatalf("unexpected single calls: %v", single)
+	}
+	for i, result := range results {
+		if result.Digest != digests[i] {
+			t.Fatalf("result %v is %v, expect %v", i, result.Digest, digests[i])
+		}
+		if result.Digest == missing {
+			if result.Err == nil || result.Block != nil {
+				t.Fatalf("expect error for %v", missing)
+			}
+			continue
+		}
+		if result.Err != nil || result.Block.Digest != digests[i] {
+			t.Fatalf("unexpected result %v: %+v", i, result)
+		}
+	}
+}
+
+func TestMultiGetTransactionBlocks_Error(t *testing.T) {
+	single := 0
+	rateLimited := &Error{Code: -32000, Message: "rate limited"}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		if method == "sui_getTransactionBlock" {
+			single++
+		}
+		return nil, rateLimited
+	})
+	testClient.SetConcurrency(1)
+
+	results := testClient.MultiGetTransactionBlocks(testDigests(60), nil)
+	if single != 0 {
+		t.Fatalf("fallback on a non not-found error: %v calls", single)
+	}
+	for _, result := range results {
+		var rpcErr *Error
+		if !errors.As(result.Err, &rpcErr) || rpcErr.Message != rateLimited.Message {
+			t.Fatalf("unexpected error of %v: %v", result.Digest, result.Err)
+		}
+	}
+}
+
+func TestMultiGetObjects_Chunks(t *testing.T) {
+	var lock sync.Mutex
+	var chunkSizes []int
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		lock.Lock()
+		defer lock.Unlock()
+		var objectIds []string
+		json.Unmarshal(params[0], &objectIds)
+		chunkSizes = append(chunkSizes, len(objectIds))
+		var objects []interface{}
+		for _, objectId := range objectIds {
+			if objectId == "0x42" {
+				objects = append(objects, map[string]interface{}{"error": map[string]string{"code": "notExists", "object_id": objectId}})
+				continue
+			}
+			objects = append(objects, map[string]interface{}{"data": map[string]string{"objectId": objectId, "version": "1"}})
+		}
+		return objects, nil
+	})
+
+	var objectIds []string
+	for i := 0; i < 60; i++ {
+		objectIds = append(objectIds, fmt.Sprintf("0x%x", i))
+	}
+	results := testClient.MultiGetObjects(objectIds, nil)
+	sort.Ints(chunkSizes)
+	if fmt.Sprint(chunkSizes) != "[10 50]" {
+		t.Fatalf("unexpected chunks: %v", chunkSizes)
+	}
+	for i, result := range results {
+		if result.ObjectID != objectIds[i] {
+			t.Fatalf("result %v is %v, expect %v", i, result.ObjectID, objectIds[i])
+		}
+		if result.ObjectID == "0x42" {
+			var notExists *types.ObjectNotExistsError
+			if !errors.As(result.Err, &notExists) {
+				t.Fatalf("unexpected error of 0x42: %v", result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.Object.Data.ObjectID != objectIds[i] {
+			t.Fatalf("unexpected result %v: %+v", i, result)
+		}
+	}
+}
+
+func TestGetObject_Errors(t *testing.T) {
+	responses := map[string]string{
+		"0x42": `{"error":{"code":"notExists","object_id":"0x42"}}`,
+		"0x43": `{"error":{"code":"deleted","object_id":"0x43","version":7,"digest":"7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz"}}`,
+		"0x44": `{"data":{"objectId":"0x44","version":"3","digest":"7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz"},"error":{"code":"displayError","error":"no template"}}`,
+	}
+	pastResponses := map[uint64]string{
+		3: `{"status":"VersionFound","details":{"objectId":"0x44","version":"3","digest":"7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz"}}`,
+		2: `{"status":"VersionNotFound","details":["0x44",2]}`,
+	}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		var objectId string
+		json.Unmarshal(params[0], &objectId)
+		if method == "sui_tryGetPastObject" {
+			var version uint64
+			json.Unmarshal(params[1], &version)
+			return json.RawMessage(pastResponses[version]), nil
+		}
+		return json.RawMessage(responses[objectId]), nil
+	})
+
+	_, err := testClient.GetObject("0x42", nil)
+	var notExists *types.ObjectNotExistsError
+	if !errors.As(err, &notExists) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = testClient.GetObject("0x43", nil)
+	var deleted *types.ObjectDeletedError
+	if !errors.As(err, &deleted) || deleted.Version != "7" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the object is still returned with a display error
+	object, err := testClient.GetObject("0x44", nil)
+	if err != nil || object.Data.Version != "3" || object.Error.Code != types.DisplayErrorCode {
+		t.Fatalf("unexpected object: %+v %v", object, err)
+	}
+
+	past, err := testClient.TryGetPastObject("0x44", 3, nil)
+	if err != nil || past.Version != "3" {
+		t.Fatalf("unexpected past object: %+v %v", past, err)
+	}
+	_, err = testClient.TryGetPastObject("0x44", 2, nil)
+	var versionNotFound *types.ObjectVersionNotFoundError
+	if !errors.As(err, &versionNotFound) || versionNotFound.Version != "2" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSuiClient_QueryTransactionBlocks(t *testing.T) {
+	query := types.TransactionBlockResponseQuery{
+		Filter: types.ToAddressFilter("0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e"),
+	}
+	it := client.NewTransactionBlockIterator(query, 10, true)
+	for it.Next() {
+		fmt.Println(it.Value().Digest)
+	}
+	if it.Err() != nil {
+		panic(it.Err())
+	}
+}
+
 func TestSuiClient_TransactionsByCheckpoint(t *testing.T) {
 	transactions, _, err := client.Transactions(2038639, 1)
 	if err != nil {
@@ -230,7 +696,7 @@ func TestSuiClient_Transfer(t *testing.T) {
 	}
 	amountBig = big.NewInt(0).Sub(balanceBig, gasUsed)
 
-	tx, err := client.Transfer(types.SuiCoinType, seedBytes, sender, allObjectIds, []string{recipent}, []string{amountBig.String()}, gasUsed.String())
+	tx, err := client.Transfer(types.SuiCoinType, seedBytes, sender, allObjectIds, []string{recipent}, []string{amountBig.String()}, gasUsed.String(), nil)
 	if err != nil {
 		panic(err)
 	}
@@ -271,6 +737,24 @@ func TestSuiClient_DryRunTransactionBlock(t *testing.T) {
 	fmt.Println(result.Status())
 }
 
+func TestGasEstimator_Estimate(t *testing.T) {
+	sender := "0xc0ee0c49b3be532975fdb2c02a3ae8dea70b58f53879f70ba256974627e23ee3"
+	recipent := "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e"
+	allObjectIds, err := client.GetAllCoinObjectIds(types.SuiCoinType, sender)
+	if err != nil {
+		panic(err)
+	}
+	unsignedTx, err := client.BuildPayAllSui(sender, recipent, allObjectIds, "1100000000")
+	if err != nil {
+		panic(err)
+	}
+	estimated, budget, err := NewGasEstimator(client).Estimate(unsignedTx)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(budget, estimated.TxBytes)
+}
+
 func TestSuiClient_Pay(t *testing.T) {
 
 	seed := "dcee1121d8e62b67f9a5e8a9ebb49fe1d3b41aaf7bee77984ac4648e632cddc0"
@@ -288,7 +772,7 @@ func TestSuiClient_Pay(t *testing.T) {
 	objectIds = objectIds[1:]
 	amount := []string{"1100000", "1200000"}
 	gasBudget := "4000000"
-	tx, err := client.Pay(seedBytes, sender, gasObjectId, objectIds, recipent, amount, gasBudget)
+	tx, err := client.Pay(seedBytes, sender, gasObjectId, objectIds, recipent, amount, gasBudget, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -313,7 +797,7 @@ func TestSuiClient_PaySui(t *testing.T) {
 	amount := "1100000"
 	gasBudget := "40000000"
 
-	tx, err := client.PaySui(seedBytes, sender, objectIds, []string{recipent}, []string{amount}, gasBudget)
+	tx, err := client.PaySui(seedBytes, sender, objectIds, []string{recipent}, []string{amount}, gasBudget, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -334,7 +818,7 @@ func TestSuiClient_PayAllSui(t *testing.T) {
 		panic(err)
 	}
 	gasBudget := "3000000"
-	tx, err := client.PayAllSui(seedBytes, sender, recipent, objectIds, gasBudget)
+	tx, err := client.PayAllSui(seedBytes, sender, recipent, objectIds, gasBudget, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -359,7 +843,7 @@ func TestSuiClient_TransferSui(t *testing.T) {
 	amount := "4000000"
 	suiObjectId := objectIds[0]
 
-	tx, err := client.TransferSui(seedBytes, sender, recipent, suiObjectId, amount, gasBudget)
+	tx, err := client.TransferSui(seedBytes, sender, recipent, suiObjectId, amount, gasBudget, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -383,7 +867,7 @@ func TestSuiClient_TransferObject(t *testing.T) {
 	suiObjectId := objectIds[0]
 	gasObjectId := objectIds[1]
 
-	signAndSubmitTx, err := client.TransferObject(seedBytes, sender, recipent, suiObjectId, gasObjectId, gasBudget)
+	signAndSubmitTx, err := client.TransferObject(seedBytes, sender, recipent, suiObjectId, gasObjectId, gasBudget, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -450,3 +934,486 @@ func TestGenerateAddr(t *testing.T) {
 	fmt.Printf("addess:%v\n", keyPair.Address())
 
 }
+
+func TestPager(t *testing.T) {
+	pages := [][]int{{1, 2}, {}, {3}}
+	fetched := 0
+	pager := NewPager(func() ([]int, bool, error) {
+		page := pages[fetched]
+		fetched++
+		return page, fetched < len(pages), nil
+	})
+	var values []int
+	for pager.Next() {
+		values = append(values, *pager.Value())
+	}
+	if pager.Err() != nil || fmt.Sprint(values) != "[1 2 3]" || fetched != len(pages) {
+		t.Fatalf("unexpected values %v after %v pages: %v", values, fetched, pager.Err())
+	}
+	if pager.Next() || fetched != len(pages) {
+		t.Fatalf("fetched again after the last page")
+	}
+
+	rateLimited := errors.New("rate limited")
+	fetched = 0
+	pager = NewPager(func() ([]int, bool, error) {
+		fetched++
+		if fetched > 1 {
+			return nil, false, rateLimited
+		}
+		return []int{1}, true, nil
+	})
+	values = nil
+	for pager.Next() {
+		values = append(values, *pager.Value())
+	}
+	if !errors.Is(pager.Err(), rateLimited) || fmt.Sprint(values) != "[1]" {
+		t.Fatalf("unexpected values %v: %v", values, pager.Err())
+	}
+	if pager.Next() || fetched != 2 {
+		t.Fatalf("fetched again after an error")
+	}
+}
+
+func TestDynamicFieldIterator(t *testing.T) {
+	var cursors []string
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		if method != "suix_getDynamicFields" {
+			return nil, &Error{Code: -32601, Message: "Method not found"}
+		}
+		var cursor *string
+		json.Unmarshal(params[1], &cursor)
+		if cursor == nil {
+			cursors = append(cursors, "")
+			return types.DynamicFieldPage{Data: []types.DynamicFieldInfo{{ObjectID: "0x1"}, {ObjectID: "0x2"}}, NextCursor: "0x2", HasNextPage: true}, nil
+		}
+		cursors = append(cursors, *cursor)
+		if *cursor == "0x2" {
+			return types.DynamicFieldPage{Data: []types.DynamicFieldInfo{{ObjectID: "0x3"}}, NextCursor: "0x3"}, nil
+		}
+		return nil, &Error{Code: -32602, Message: "invalid cursor"}
+	})
+
+	it := testClient.NewDynamicFieldIterator("0x42", 2)
+	var objectIds []string
+	for it.Next() {
+		objectIds = append(objectIds, it.Value().ObjectID)
+	}
+	if it.Err() != nil || fmt.Sprint(objectIds) != "[0x1 0x2 0x3]" || fmt.Sprint(cursors) != "[ 0x2]" {
+		t.Fatalf("unexpected fields %v with cursors %v: %v", objectIds, cursors, it.Err())
+	}
+}
+
+func TestCoinIterator_Error(t *testing.T) {
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		return nil, &Error{Code: -32000, Message: "rate limited"}
+	})
+	it := testClient.NewCoinIterator("0x42", "", 0)
+	if it.Next() {
+		t.Fatalf("expect no coins")
+	}
+	var rpcErr *Error
+	if !errors.As(it.Err(), &rpcErr) || rpcErr.Message != "rate limited" {
+		t.Fatalf("unexpected error: %v", it.Err())
+	}
+}
+
+func TestCoinManager_BuildSplitCoin(t *testing.T) {
+	owner, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	coinTypes := map[string]string{
+		"0x5": "0x2::coin::Coin<0x2::sui::SUI>",
+		"0x6": "0x2::coin::Coin<0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN>",
+	}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "sui_getObject":
+			var objectId string
+			json.Unmarshal(params[0], &objectId)
+			return map[string]interface{}{"data": map[string]interface{}{
+				"objectId": objectId,
+				"version":  "7",
+				"digest":   "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf",
+				"type":     coinTypes[objectId],
+				"content": map[string]interface{}{
+					"dataType": "moveObject",
+					"type":     coinTypes[objectId],
+					"fields":   map[string]interface{}{"balance": "10000000", "id": map[string]string{"id": objectId}},
+				},
+			}}, nil
+		}
+		if result, ok := serveTestGasPrice(method, "750", time.Now().Add(time.Hour)); ok {
+			return result, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	manager, err := NewCoinManager(testClient, owner, big.NewInt(1000), 1000000)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = manager.BuildSplitCoin("0x6", 3)
+	if err == nil || !strings.Contains(err.Error(), "not a sui coin") {
+		t.Fatalf("expect error for a non sui coin: %v", err)
+	}
+	txData, err := manager.BuildSplitCoin("0x5", 3)
+	if err != nil {
+		panic(err)
+	}
+	split := txData.Kind.Commands[0].SplitCoins
+	if split == nil || len(split.Amounts) != 2 || txData.GasData.Payment[0].ObjectID != "0x5" || txData.GasData.Price != "750" {
+		t.Fatalf("unexpected split transaction: %+v", txData)
+	}
+	amount, err := bcs.Marshal(uint64(3000000))
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(txData.Kind.Inputs[0].Pure, amount) {
+		t.Fatalf("unexpected split amount: %x", txData.Kind.Inputs[0].Pure)
+	}
+}
+
+func TestGasBudget(t *testing.T) {
+	cases := []struct {
+		name                         string
+		computation, storage, rebate string
+		margin                       float64
+		expect                       uint64
+	}{
+		{"net cost", "1000000", "2000000", "500000", 1.2, 3000000},
+		{"rebate larger than storage", "1000000", "2000000", "9000000", 1.2, 1200000},
+		{"no margin", "1000000", "2000000", "0", 0, 3000000},
+		{"fraction rounded up", "1000001", "0", "0", 1.5, 1500002},
+		{"below MinGasFee", "1000", "0", "0", 1.2, types.MinGasFee},
+		{"above MaxGasFee", "50000000000", "1000", "0", 1.2, types.MaxGasFee},
+		{"above uint64", "18446744073709551615", "18446744073709551615", "0", 2, types.MaxGasFee},
+	}
+	for _, c := range cases {
+		gasUsed := types.GasUsed{ComputationCost: c.computation, StorageCost: c.storage, StorageRebate: c.rebate}
+		budget, err := gasBudget(gasUsed, c.margin, types.MinGasFee, types.MaxGasFee)
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if budget != c.expect {
+			t.Fatalf("%v: budget %v, expect %v", c.name, budget, c.expect)
+		}
+	}
+	budget, err := gasBudget(types.GasUsed{ComputationCost: "60000000000", StorageCost: "0", StorageRebate: "0"}, 1, 0, 0)
+	if err != nil || budget != 60000000000 {
+		t.Fatalf("unexpected budget without a max: %v %v", budget, err)
+	}
+	_, err = gasBudget(types.GasUsed{ComputationCost: "1.5", StorageCost: "0", StorageRebate: "0"}, 1, 0, 0)
+	if err == nil {
+		t.Fatalf("expect error for a bad cost")
+	}
+}
+
+// serveTestGasPrice answers the system state behind ReferenceGasPrice, epochEnd is when the epoch is due to end
+func serveTestGasPrice(method, price string, epochEnd time.Time) (interface{}, bool) {
+	if method != "suix_getLatestSuiSystemState" {
+		return nil, false
+	}
+	start := epochEnd.Add(-24 * time.Hour).UnixMilli()
+	return map[string]string{
+		"epoch":                 "100",
+		"referenceGasPrice":     price,
+		"epochStartTimestampMs": strconv.FormatInt(start, 10),
+		"epochDurationMs":       strconv.FormatInt((24 * time.Hour).Milliseconds(), 10),
+	}, true
+}
+
+func TestReferenceGasPrice_Cache(t *testing.T) {
+	price, epochEnd := "750", time.Now().Add(time.Hour)
+	calls := map[string]int{}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		calls[method]++
+		if result, ok := serveTestGasPrice(method, price, epochEnd); ok {
+			return result, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+
+	for i := 0; i < 3; i++ {
+		result, err := testClient.ReferenceGasPrice()
+		if err != nil {
+			panic(err)
+		}
+		// one system state call and nothing else while the epoch runs
+		if result.String() != "750" || len(calls) != 1 || calls["suix_getLatestSuiSystemState"] != 1 {
+			t.Fatalf("unexpected price %v after calls %v", result, calls)
+		}
+	}
+
+	// the epoch ended, the node reports the next one
+	testClient.gasPriceExpiry = time.Now().Add(-time.Second)
+	price, epochEnd = "800", time.Now().Add(24*time.Hour)
+	result, err := testClient.ReferenceGasPrice()
+	if err != nil {
+		panic(err)
+	}
+	if result.String() != "800" || calls["suix_getLatestSuiSystemState"] != 2 {
+		t.Fatalf("price %v not refreshed after the epoch end, calls %v", result, calls)
+	}
+
+	// the epoch change is late, the price is kept for the retry interval only
+	testClient.gasPriceExpiry = time.Now().Add(-time.Second)
+	epochEnd = time.Now().Add(-time.Minute)
+	_, err = testClient.ReferenceGasPrice()
+	if err != nil {
+		panic(err)
+	}
+	if retry := time.Until(testClient.gasPriceExpiry); retry <= 0 || retry > gasPriceRetryInterval {
+		t.Fatalf("unexpected retry after a late epoch change: %v", retry)
+	}
+	_, err = testClient.ReferenceGasPrice()
+	if err != nil || calls["suix_getLatestSuiSystemState"] != 3 || len(calls) != 1 {
+		t.Fatalf("unexpected calls %v: %v", calls, err)
+	}
+
+	result, err = testClient.GasPrice(1.2)
+	if err != nil || result.String() != "960" {
+		t.Fatalf("unexpected gas price: %v %v", result, err)
+	}
+	for _, multiplier := range []float64{0, 0.5, -1} {
+		_, err = testClient.GasPrice(multiplier)
+		if err == nil {
+			t.Fatalf("expect error for multiplier %v", multiplier)
+		}
+	}
+}
+
+func TestSetGasData_ExcludeInputs(t *testing.T) {
+	sender, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	coin := func(objectId, balance string) types.CoinData {
+		return types.CoinData{CoinType: "0x2::sui::SUI", CoinObjectID: objectId, Version: "7", Digest: "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf", Balance: balance}
+	}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		if method == "suix_getCoins" {
+			// the input coin is the largest, it would be picked first if it was not excluded
+			return types.CoinObj{Data: []types.CoinData{coin("0x5", "90000000"), coin("0x7", "4000000"), coin("0x8", "3000000")}}, nil
+		}
+		if result, ok := serveTestGasPrice(method, "750", time.Now().Add(time.Hour)); ok {
+			return result, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+
+	payment, err := testClient.SelectGasPayment(sender.Address(), 5000000, []string{"0x5"})
+	if err != nil {
+		panic(err)
+	}
+	if len(payment) != 2 || payment[0].ObjectID != "0x7" || payment[1].ObjectID != "0x8" {
+		t.Fatalf("unexpected payment: %+v", payment)
+	}
+
+	builder := types.NewProgrammableTransactionBuilder()
+	recipient, err := builder.Pure(types.Address(sender.Address()))
+	if err != nil {
+		panic(err)
+	}
+	builder.TransferObjects([]types.Argument{builder.OwnedObject(types.ObjectRef{ObjectID: "0x5", Version: 7, Digest: "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf"})}, recipient)
+	txData := types.NewTransactionData(sender.Address(), builder.Finish(), types.GasData{})
+	err = testClient.SetGasData(txData, GasConfig{Budget: 5000000})
+	if err != nil {
+		panic(err)
+	}
+	for _, payment := range txData.GasData.Payment {
+		if types.NormalizeAddress(payment.ObjectID) == types.NormalizeAddress("0x5") {
+			t.Fatalf("input object pays gas: %+v", txData.GasData)
+		}
+	}
+	if len(txData.GasData.Payment) != 2 || txData.GasData.Price != "750" || txData.GasData.Owner != sender.Address() {
+		t.Fatalf("unexpected gas data: %+v", txData.GasData)
+	}
+
+	_, err = testClient.SelectGasPayment(sender.Address(), 8000000, []string{"0x5"})
+	var insufficient *types.InsufficientBalanceError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("expect insufficient balance without the input coin: %v", err)
+	}
+}
+
+func TestCheckSignatures_ZkLogin(t *testing.T) {
+	ephemeral, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	inputs := crypto.ZkLoginInputs{
+		ProofPoints:      crypto.ZkLoginProofPoints{A: []string{"1"}, B: [][]string{{"2"}}, C: []string{"3"}},
+		IssBase64Details: crypto.ZkLoginClaim{Value: "yJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLC", IndexMod4: 1},
+		HeaderBase64:     "h",
+		AddressSeed:      "13322897930163218532266430409510394316985274769125667290600321564259466511711",
+	}
+	sender := "0xf7badc2b245c7f74d7509a4aa357ecf80a29e7713fb4c44b0e7541ec43885ee1"
+	txBytes, err := testTxData(sender, sender).TxBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	signature, err := SignTransactionWithZkLogin(ephemeral, inputs, 10, txBytes)
+	if err != nil {
+		panic(err)
+	}
+	if err := CheckSignatures(txBytes, []string{signature}); err != nil {
+		t.Fatalf("check signatures error: %v", err)
+	}
+	inputs.AddressSeed = "0"
+	signature, err = SignTransactionWithZkLogin(ephemeral, inputs, 10, txBytes)
+	if err != nil {
+		panic(err)
+	}
+	if err := CheckSignatures(txBytes, []string{signature}); err == nil {
+		t.Fatalf("zkLogin signature of another address accepted")
+	}
+	plain, err := SignTransaction(ephemeral, txBytes)
+	if err != nil {
+		panic(err)
+	}
+	if err := CheckSignatures(txBytes, []string{plain}); err == nil {
+		t.Fatalf("ephemeral key signature accepted for the zkLogin sender")
+	}
+}
+
+func TestEnvelope_AddSignatureAndSubmit(t *testing.T) {
+	sender, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	sponsor, err := crypto.NewKeyPairFromSeed(append(make([]byte, crypto.SeedLength-1), 1))
+	if err != nil {
+		panic(err)
+	}
+	executed := 0
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "sui_getChainIdentifier":
+			return "35834a8a", nil
+		case "sui_executeTransactionBlock":
+			executed++
+			return types.TransactionBlock{Digest: "executed"}, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	txBytes, err := testTxData(sender.Address(), sponsor.Address()).TxBytes()
+	if err != nil {
+		panic(err)
+	}
+	envelope, err := testClient.NewEnvelope(&types.UnsignedTx{TxBytes: txBytes}, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	senderSignature, err := SignTransaction(sender, txBytes)
+	if err != nil {
+		panic(err)
+	}
+	if err := envelope.AddSignature(sponsor.Address(), senderSignature); err == nil {
+		t.Fatalf("sender signature accepted for the sponsor")
+	}
+	if err := envelope.AddSignature(sender.Address(), "not base64"); err == nil {
+		t.Fatalf("invalid signature accepted")
+	}
+	if err := envelope.AddSignature(sender.Address(), senderSignature); err != nil {
+		panic(err)
+	}
+	if err := Sign(envelope, sponsor); err != nil {
+		panic(err)
+	}
+
+	tampered := *envelope
+	tampered.Summary.GasBudget = "1"
+	if _, err := testClient.Submit(&tampered, nil); err == nil || executed != 0 {
+		t.Fatalf("tampered envelope submitted: %v", err)
+	}
+	swapped := *envelope
+	swapped.Signatures = map[string]string{
+		types.NormalizeAddress(sender.Address()):  envelope.Signatures[types.NormalizeAddress(sponsor.Address())],
+		types.NormalizeAddress(sponsor.Address()): senderSignature,
+	}
+	if _, err := testClient.Submit(&swapped, nil); err == nil || executed != 0 {
+		t.Fatalf("envelope with swapped signatures submitted: %v", err)
+	}
+	block, err := testClient.Submit(envelope, nil)
+	if err != nil {
+		panic(err)
+	}
+	if block.Digest != "executed" || executed != 1 {
+		t.Fatalf("unexpected submit: %+v", block)
+	}
+}
+
+func TestCoinManager_DustThreshold(t *testing.T) {
+	owner, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	for _, threshold := range []*big.Int{nil, big.NewInt(-1)} {
+		_, err := NewCoinManager(testClient, owner, threshold, 1000000)
+		if err == nil {
+			t.Fatalf("expect error for dust threshold %v", threshold)
+		}
+	}
+	manager, err := NewCoinManager(testClient, owner, big.NewInt(0), 1000000)
+	if err != nil {
+		panic(err)
+	}
+	manager.DustThreshold = nil
+	_, err = manager.MergeDust(types.SuiCoinType)
+	if err == nil || !strings.Contains(err.Error(), "dust threshold") {
+		t.Fatalf("expect error for a nil dust threshold: %v", err)
+	}
+}
+
+func TestSignAndSubmitTx_Expiration(t *testing.T) {
+	sender, err := crypto.NewKeyPairFromSeed(make([]byte, crypto.SeedLength))
+	if err != nil {
+		panic(err)
+	}
+	executed := 0
+	testClient := newTestNode(t, func(method string, params []json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "sui_getLatestCheckpointSequenceNumber":
+			return "1000", nil
+		case "sui_getCheckpoint":
+			return map[string]interface{}{"epoch": "101"}, nil
+		case "sui_executeTransactionBlock":
+			executed++
+			return types.TransactionBlock{Digest: "executed"}, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	txData := testTxData(sender.Address(), sender.Address())
+	txData.SetExpirationEpoch(100)
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		panic(err)
+	}
+	_, err = testClient.SignAndSubmitTx(sender, &types.UnsignedTx{TxBytes: txBytes}, nil)
+	if _, ok := err.(*types.TransactionExpiredError); !ok || executed != 0 {
+		t.Fatalf("expired transaction submitted: %v", err)
+	}
+
+	txData.SetExpirationEpoch(101)
+	txBytes, err = txData.TxBytes()
+	if err != nil {
+		panic(err)
+	}
+	// TransactionData::V1 with a ChangeEpoch kind is not parsed, so its expiration is not checked
+	for _, txBytes := range []string{txBytes, base64.StdEncoding.EncodeToString([]byte{0, 1})} {
+		_, err = testClient.SignAndSubmitTx(sender, &types.UnsignedTx{TxBytes: txBytes}, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", txBytes, err)
+		}
+	}
+	if executed != 2 {
+		t.Fatalf("unexpected executions: %v", executed)
+	}
+}
@@ -0,0 +1,154 @@
+package go_sui_sdk
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/types"
+	"math/big"
+)
+
+// GetCoinMetadata always asks the node, use CoinMetadata for the cached value
+func (si *SuiClient) GetCoinMetadata(coinType types.CoinType) (*types.CoinMetadata, error) {
+	var result *types.CoinMetadata
+	params := Params{}
+	params.AddValue(coinType)
+	err := si.post("suix_getCoinMetadata", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("coin metadata not found: %v", coinType)
+	}
+	return result, nil
+}
+
+// CoinMetadata returns the metadata of coinType, fetched once and cached by the client
+func (si *SuiClient) CoinMetadata(coinType types.CoinType) (*types.CoinMetadata, error) {
+	coinType = types.CoinType(types.NormalizeType(coinType.String()))
+	si.metadataLock.RLock()
+	metadata, ok := si.coinMetadata[coinType]
+	si.metadataLock.RUnlock()
+	if ok {
+		return metadata, nil
+	}
+	metadata, err := si.GetCoinMetadata(coinType)
+	if err != nil {
+		return nil, err
+	}
+	si.SetCoinMetadata(coinType, metadata)
+	return metadata, nil
+}
+
+// SetCoinMetadata preloads the cache, e.g. for coins without on-chain metadata
+func (si *SuiClient) SetCoinMetadata(coinType types.CoinType, metadata *types.CoinMetadata) {
+	si.metadataLock.Lock()
+	defer si.metadataLock.Unlock()
+	si.coinMetadata[types.CoinType(types.NormalizeType(coinType.String()))] = metadata
+}
+
+func (si *SuiClient) GetTotalSupply(coinType types.CoinType) (*big.Int, error) {
+	result := &types.Supply{}
+	params := Params{}
+	params.AddValue(coinType)
+	err := si.post("suix_getTotalSupply", params, result)
+	if err != nil {
+		return nil, err
+	}
+	supplyBig, ok := big.NewInt(0).SetString(result.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("parse big error: %v", result.Value)
+	}
+	return supplyBig, nil
+}
+
+// Amount formats a base unit value of coinType with its cached metadata
+func (si *SuiClient) Amount(coinType types.CoinType, value *big.Int) (types.Amount, error) {
+	metadata, err := si.CoinMetadata(coinType)
+	if err != nil {
+		return types.Amount{}, err
+	}
+	return metadata.Amount(value), nil
+}
+
+// ParseAmount parses a human amount like "1.5 USDC" of coinType into base units
+func (si *SuiClient) ParseAmount(coinType types.CoinType, amount string) (*big.Int, error) {
+	metadata, err := si.CoinMetadata(coinType)
+	if err != nil {
+		return nil, err
+	}
+	result, err := metadata.ParseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// BalanceAmount is Balance with the coin decimals and symbol
+func (si *SuiClient) BalanceAmount(coinType types.CoinType, address string) (types.Amount, error) {
+	balance, err := si.Balance(coinType, address)
+	if err != nil {
+		return types.Amount{}, err
+	}
+	return si.Amount(coinType, balance)
+}
+
+// GetAllCoins returns one page of the coins of every type owned by address, limit 0 uses the node default
+func (si *SuiClient) GetAllCoins(address, cursor string, limit uint64) (*types.CoinObj, error) {
+	result := &types.CoinObj{}
+	params := Params{}
+	params.AddValue(address)
+	params.AddValue(cursorParam(cursor))
+	params.AddValue(limitParam(limit))
+	err := si.post("suix_getAllCoins", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (si *SuiClient) getCoinsPage(coinType types.CoinType, address, cursor string, limit uint64) (*types.CoinObj, error) {
+	if coinType == "" {
+		return si.GetAllCoins(address, cursor, limit)
+	}
+	result := &types.CoinObj{}
+	params := Params{}
+	params.AddValue(address)
+	params.AddValue(coinType)
+	params.AddValue(cursorParam(cursor))
+	params.AddValue(limitParam(limit))
+	err := si.post("suix_getCoins", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type CoinIterator struct {
+	pager  *Pager[types.CoinData]
+	cursor string
+}
+
+// NewCoinIterator walks the coins of address, an empty coinType walks every coin type
+func (si *SuiClient) NewCoinIterator(address string, coinType types.CoinType, limit uint64) *CoinIterator {
+	it := &CoinIterator{}
+	it.pager = NewPager(func() ([]types.CoinData, bool, error) {
+		page, err := si.getCoinsPage(coinType, address, it.cursor, limit)
+		if err != nil {
+			return nil, false, err
+		}
+		it.cursor = page.NextCursor
+		return page.Data, page.HasNextPage, nil
+	})
+	return it
+}
+
+func (it *CoinIterator) Next() bool {
+	return it.pager.Next()
+}
+
+func (it *CoinIterator) Value() *types.CoinData {
+	return it.pager.Value()
+}
+
+func (it *CoinIterator) Err() error {
+	return it.pager.Err()
+}
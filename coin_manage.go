@@ -0,0 +1,292 @@
+package go_sui_sdk
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/crypto"
+	"github.com/ltp456/go-sui-sdk/types"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+const defaultMaxMergeInputs = 250
+
+// CoinManager keeps the coin set of the signer's address usable: it merges dust coins
+// and pre-splits gas coins, building programmable transactions locally.
+// unsafe_mergeCoins merges one coin per transaction, a local MergeCoins takes up to MaxMergeInputs.
+type CoinManager struct {
+	client *SuiClient
+	signer crypto.Signer
+	owner  string
+
+	// DustThreshold marks coins with a smaller balance as dust
+	DustThreshold *big.Int
+	// MinDustCoins is the number of dust coins below which MergeDust does nothing
+	MinDustCoins int
+	// MaxMergeInputs caps the coins merged by one transaction
+	MaxMergeInputs int
+	GasBudget      uint64
+	Options        *types.TransactionBlockResponseOptions
+}
+
+func NewCoinManager(client *SuiClient, signer crypto.Signer, dustThreshold *big.Int, gasBudget uint64) (*CoinManager, error) {
+	if dustThreshold == nil || dustThreshold.Sign() < 0 {
+		return nil, fmt.Errorf("invalid dust threshold: %v", dustThreshold)
+	}
+	owner, err := crypto.SignerAddress(signer)
+	if err != nil {
+		return nil, err
+	}
+	return &CoinManager{
+		client:         client,
+		signer:         signer,
+		owner:          owner,
+		DustThreshold:  dustThreshold,
+		MinDustCoins:   2,
+		MaxMergeInputs: defaultMaxMergeInputs,
+		GasBudget:      gasBudget,
+	}, nil
+}
+
+func (cm *CoinManager) Owner() string {
+	return cm.owner
+}
+
+// usableCoins returns the unlocked and unreserved coins of coinType, largest first
+func (cm *CoinManager) usableCoins(coinType types.CoinType) ([]types.CoinData, error) {
+	var coins []types.CoinData
+	it := cm.client.NewCoinIterator(cm.owner, coinType, 0)
+	for it.Next() {
+		coin := *it.Value()
+		if coin.IsLocked() || cm.client.isReserved(coin.CoinObjectID) {
+			continue
+		}
+		coins = append(coins, coin)
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	balances := map[string]*big.Int{}
+	for _, coin := range coins {
+		balance, err := coin.BigBalance()
+		if err != nil {
+			return nil, err
+		}
+		balances[coin.CoinObjectID] = balance
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return balances[coins[i].CoinObjectID].Cmp(balances[coins[j].CoinObjectID]) > 0
+	})
+	return coins, nil
+}
+
+func (cm *CoinManager) gasData(gasCoin types.ObjectRef) (types.GasData, error) {
+	price, err := cm.client.ReferenceGasPrice()
+	if err != nil {
+		return types.GasData{}, err
+	}
+	return types.GasData{
+		Payment: []types.Payment{types.NewPayment(gasCoin)},
+		Owner:   cm.owner,
+		Price:   price.String(),
+		Budget:  strconv.FormatUint(cm.GasBudget, 10),
+	}, nil
+}
+
+// BuildMergeCoins merges sources into destination, SUI sources are merged into the gas coin when destination is nil
+func (cm *CoinManager) BuildMergeCoins(destination *types.ObjectRef, sources []types.ObjectRef, gasCoin types.ObjectRef) (*types.TransactionData, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no coins to merge")
+	}
+	builder := types.NewProgrammableTransactionBuilder()
+	target := types.GasCoinArgument()
+	if destination != nil {
+		target = builder.OwnedObject(*destination)
+	}
+	var arguments []types.Argument
+	for _, source := range sources {
+		arguments = append(arguments, builder.OwnedObject(source))
+	}
+	builder.MergeCoins(target, arguments)
+	gasData, err := cm.gasData(gasCoin)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTransactionData(cm.owner, builder.Finish(), gasData), nil
+}
+
+// MergeDust merges the dust coins of coinType into its largest coin, one transaction per MaxMergeInputs coins.
+// Nothing is sent when there are fewer than MinDustCoins dust coins.
+func (cm *CoinManager) MergeDust(coinType types.CoinType) ([]*types.TransactionBlock, error) {
+	if cm.DustThreshold == nil || cm.DustThreshold.Sign() < 0 {
+		return nil, fmt.Errorf("invalid dust threshold: %v", cm.DustThreshold)
+	}
+	coins, err := cm.usableCoins(coinType)
+	if err != nil {
+		return nil, err
+	}
+	isSui := types.NormalizeType(coinType.String()) == types.NormalizeType(types.SuiCoinType.String())
+	var destination *types.CoinData
+	var dust []types.CoinData
+	for i := range coins {
+		balance, err := coins[i].BigBalance()
+		if err != nil {
+			return nil, err
+		}
+		// the largest coin keeps the merged balance, even when it is dust itself
+		if i == 0 || balance.Cmp(cm.DustThreshold) >= 0 {
+			if i == 0 {
+				destination = &coins[i]
+			}
+			continue
+		}
+		dust = append(dust, coins[i])
+	}
+	if destination == nil || len(dust) < cm.MinDustCoins || len(dust) == 0 {
+		return nil, nil
+	}
+	gasCoinId := destination.CoinObjectID
+	if !isSui {
+		suiCoins, err := cm.usableCoins(types.SuiCoinType)
+		if err != nil {
+			return nil, err
+		}
+		if len(suiCoins) == 0 {
+			return nil, fmt.Errorf("no sui coin to pay gas for %v", cm.owner)
+		}
+		gasCoinId = suiCoins[0].CoinObjectID
+	}
+	maxInputs := cm.MaxMergeInputs
+	if maxInputs <= 0 {
+		maxInputs = defaultMaxMergeInputs
+	}
+
+	var result []*types.TransactionBlock
+	for start := 0; start < len(dust); start += maxInputs {
+		end := start + maxInputs
+		if end > len(dust) {
+			end = len(dust)
+		}
+		var sources []types.ObjectRef
+		for _, coin := range dust[start:end] {
+			ref, err := coin.ObjectRef()
+			if err != nil {
+				return result, err
+			}
+			sources = append(sources, ref)
+		}
+		// versions change after every merge, read the current refs
+		gasCoin, err := cm.client.objectRef(gasCoinId)
+		if err != nil {
+			return result, err
+		}
+		var destinationRef *types.ObjectRef
+		if !isSui {
+			ref, err := cm.client.objectRef(destination.CoinObjectID)
+			if err != nil {
+				return result, err
+			}
+			destinationRef = &ref
+		}
+		txData, err := cm.BuildMergeCoins(destinationRef, sources, gasCoin)
+		if err != nil {
+			return result, err
+		}
+		transaction, err := cm.submit(txData)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, transaction)
+	}
+	return result, nil
+}
+
+// BuildSplitCoin splits the SUI coin into count coins of equal balance, the gas budget is kept out
+// of the split; the coin itself pays gas and stays as one of the count coins.
+func (cm *CoinManager) BuildSplitCoin(coinId string, count int) (*types.TransactionData, error) {
+	if count < 2 {
+		return nil, fmt.Errorf("split count must be at least 2: %v", count)
+	}
+	object, err := cm.client.GetObject(coinId, &types.ObjectDataOptions{ShowType: true, ShowContent: true})
+	if err != nil {
+		return nil, err
+	}
+	// the split coin pays gas, so it must be a SUI coin
+	if types.NormalizeType(object.Data.Type) != types.NormalizeType(types.SuiCoinResType.String()) {
+		return nil, fmt.Errorf("coin %v is %v, not a sui coin", coinId, object.Data.Type)
+	}
+	var coin struct {
+		Balance types.MoveU64 `json:"balance"`
+	}
+	err = object.Data.DecodeFields(&coin)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(coin.Balance) <= cm.GasBudget {
+		return nil, fmt.Errorf("coin %v balance %v does not cover gas budget %v", coinId, coin.Balance, cm.GasBudget)
+	}
+	amount := (uint64(coin.Balance) - cm.GasBudget) / uint64(count)
+	if amount == 0 {
+		return nil, fmt.Errorf("coin %v balance %v is too small to split into %v", coinId, coin.Balance, count)
+	}
+	builder := types.NewProgrammableTransactionBuilder()
+	var amounts []types.Argument
+	for i := 0; i < count-1; i++ {
+		argument, err := builder.Pure(amount)
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, argument)
+	}
+	split := builder.SplitCoins(types.GasCoinArgument(), amounts)
+	var coins []types.Argument
+	for i := range amounts {
+		coins = append(coins, types.NestedResultArgument(split.Index, uint16(i)))
+	}
+	recipient, err := builder.Pure(types.Address(cm.owner))
+	if err != nil {
+		return nil, err
+	}
+	builder.TransferObjects(coins, recipient)
+	gasCoin, err := object.Data.ObjectRef()
+	if err != nil {
+		return nil, err
+	}
+	gasData, err := cm.gasData(gasCoin)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTransactionData(cm.owner, builder.Finish(), gasData), nil
+}
+
+// SplitCoin pre-splits a SUI coin for sending transactions in parallel, see BuildSplitCoin
+func (cm *CoinManager) SplitCoin(coinId string, count int) (*types.TransactionBlock, error) {
+	txData, err := cm.BuildSplitCoin(coinId, count)
+	if err != nil {
+		return nil, err
+	}
+	return cm.submit(txData)
+}
+
+func (cm *CoinManager) submit(txData *types.TransactionData) (*types.TransactionBlock, error) {
+	unsignedTx, err := txData.UnsignedTx()
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := cm.client.SignAndSubmitTx(cm.signer, unsignedTx, cm.Options)
+	if err != nil {
+		return nil, err
+	}
+	if status := transaction.Status(); status.Status != types.TxSuccess {
+		return transaction, fmt.Errorf("transaction %v failed: %v", transaction.Digest, status.Error)
+	}
+	return transaction, nil
+}
+
+func (si *SuiClient) objectRef(objectId string) (types.ObjectRef, error) {
+	object, err := si.GetObject(objectId, nil)
+	if err != nil {
+		return types.ObjectRef{}, err
+	}
+	return object.Data.ObjectRef()
+}
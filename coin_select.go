@@ -0,0 +1,236 @@
+package go_sui_sdk
+
+import (
+	"github.com/ltp456/go-sui-sdk/types"
+	"math/big"
+	"sort"
+)
+
+type CoinSelectStrategy int
+
+const (
+	// LargestFirst takes the largest coins until the amount is covered
+	LargestFirst CoinSelectStrategy = iota
+	// SmallestFirst spends the smallest coins first to clean up dust
+	SmallestFirst
+	// MinimizeInputs uses as few coins as possible and the smallest last coin that still covers the amount
+	MinimizeInputs
+	// ExactMatch prefers a single coin holding exactly the amount, falling back to MinimizeInputs
+	ExactMatch
+)
+
+type CoinSelectOptions struct {
+	Strategy CoinSelectStrategy
+	// MaxInputs caps the number of selected coins, 0 for no limit
+	MaxInputs int
+	// Exclude skips these coin object ids, e.g. the gas coin
+	Exclude []string
+	// Reserve marks the selected coins reserved on the client until ReleaseCoins
+	Reserve bool
+}
+
+type selectCoin struct {
+	coin    types.CoinData
+	balance *big.Int
+}
+
+// SelectCoins picks coins covering amount, locked coins are never selected.
+// A shortfall is reported as *types.InsufficientBalanceError.
+func SelectCoins(coins []types.CoinData, amount *big.Int, options *CoinSelectOptions) ([]types.CoinData, error) {
+	if options == nil {
+		options = &CoinSelectOptions{}
+	}
+	exclude := map[string]bool{}
+	for _, id := range options.Exclude {
+		exclude[types.NormalizeAddress(id)] = true
+	}
+	var candidates []selectCoin
+	coinType := ""
+	for _, coin := range coins {
+		if coin.IsLocked() || exclude[types.NormalizeAddress(coin.CoinObjectID)] {
+			continue
+		}
+		balance, err := coin.BigBalance()
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, selectCoin{coin: coin, balance: balance})
+		coinType = coin.CoinType
+	}
+	// largest first, ties by object id to keep the selection stable
+	sort.Slice(candidates, func(i, j int) bool {
+		if cmp := candidates[i].balance.Cmp(candidates[j].balance); cmp != 0 {
+			return cmp > 0
+		}
+		return candidates[i].coin.CoinObjectID < candidates[j].coin.CoinObjectID
+	})
+	maxInputs := options.MaxInputs
+	if maxInputs <= 0 || maxInputs > len(candidates) {
+		maxInputs = len(candidates)
+	}
+
+	var selected []selectCoin
+	switch options.Strategy {
+	case SmallestFirst:
+		selected = selectSmallestFirst(candidates, amount, maxInputs)
+	case ExactMatch:
+		for _, candidate := range candidates {
+			if candidate.balance.Cmp(amount) == 0 {
+				selected = []selectCoin{candidate}
+				break
+			}
+		}
+		if selected == nil {
+			selected = selectMinimizeInputs(candidates, amount, maxInputs)
+		}
+	case MinimizeInputs:
+		selected = selectMinimizeInputs(candidates, amount, maxInputs)
+	default:
+		selected = selectLargestFirst(candidates, amount, maxInputs)
+	}
+	if selected == nil {
+		available := big.NewInt(0)
+		for _, candidate := range candidates[:maxInputs] {
+			available.Add(available, candidate.balance)
+		}
+		if coinType == "" && len(coins) > 0 {
+			coinType = coins[0].CoinType
+		}
+		return nil, &types.InsufficientBalanceError{
+			CoinType:  coinType,
+			Required:  new(big.Int).Set(amount),
+			Available: available,
+			MaxInputs: options.MaxInputs,
+		}
+	}
+	result := make([]types.CoinData, 0, len(selected))
+	for _, item := range selected {
+		result = append(result, item.coin)
+	}
+	return result, nil
+}
+
+// selectLargestFirst returns nil when the largest maxInputs coins can not cover amount
+func selectLargestFirst(candidates []selectCoin, amount *big.Int, maxInputs int) []selectCoin {
+	if amount.Sign() <= 0 {
+		return []selectCoin{}
+	}
+	total := big.NewInt(0)
+	for i := 0; i < maxInputs; i++ {
+		total.Add(total, candidates[i].balance)
+		if total.Cmp(amount) >= 0 {
+			return candidates[:i+1]
+		}
+	}
+	return nil
+}
+
+func selectMinimizeInputs(candidates []selectCoin, amount *big.Int, maxInputs int) []selectCoin {
+	selected := selectLargestFirst(candidates, amount, maxInputs)
+	if len(selected) == 0 {
+		return selected
+	}
+	// swap the last coin for the smallest one that still covers the rest
+	last := len(selected) - 1
+	rest := new(big.Int).Set(amount)
+	for _, item := range selected[:last] {
+		rest.Sub(rest, item.balance)
+	}
+	best := last
+	for i := len(candidates) - 1; i > last; i-- {
+		if candidates[i].balance.Cmp(rest) >= 0 {
+			best = i
+			break
+		}
+	}
+	result := append([]selectCoin{}, selected[:last]...)
+	return append(result, candidates[best])
+}
+
+// selectSmallestFirst slides a window of at most maxInputs coins up from the smallest
+func selectSmallestFirst(candidates []selectCoin, amount *big.Int, maxInputs int) []selectCoin {
+	if amount.Sign() <= 0 {
+		return []selectCoin{}
+	}
+	var window []selectCoin
+	total := big.NewInt(0)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		window = append(window, candidates[i])
+		total.Add(total, candidates[i].balance)
+		if len(window) > maxInputs {
+			total.Sub(total, window[0].balance)
+			window = window[1:]
+		}
+		if total.Cmp(amount) >= 0 {
+			return window
+		}
+	}
+	return nil
+}
+
+// SelectCoins reads the coins of owner and picks coins of coinType covering amount,
+// coins reserved on this client are skipped.
+func (si *SuiClient) SelectCoins(owner string, coinType types.CoinType, amount *big.Int, options *CoinSelectOptions) ([]types.CoinData, error) {
+	var coins []types.CoinData
+	it := si.NewCoinIterator(owner, coinType, 0)
+	for it.Next() {
+		coins = append(coins, *it.Value())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	if options == nil {
+		options = &CoinSelectOptions{}
+	}
+	si.reserveLock.Lock()
+	defer si.reserveLock.Unlock()
+	selectOptions := *options
+	selectOptions.Exclude = append([]string{}, options.Exclude...)
+	for id := range si.reserved {
+		selectOptions.Exclude = append(selectOptions.Exclude, id)
+	}
+	selected, err := SelectCoins(coins, amount, &selectOptions)
+	if err != nil {
+		if insufficient, ok := err.(*types.InsufficientBalanceError); ok {
+			insufficient.CoinType = coinType.String()
+		}
+		return nil, err
+	}
+	if options.Reserve {
+		for _, coin := range selected {
+			si.reserved[types.NormalizeAddress(coin.CoinObjectID)] = true
+		}
+	}
+	return selected, nil
+}
+
+// ReserveCoins keeps the coins out of SelectCoins, e.g. while a transaction spending them is in flight
+func (si *SuiClient) ReserveCoins(objectIds ...string) {
+	si.reserveLock.Lock()
+	defer si.reserveLock.Unlock()
+	for _, id := range objectIds {
+		si.reserved[types.NormalizeAddress(id)] = true
+	}
+}
+
+func (si *SuiClient) isReserved(objectId string) bool {
+	si.reserveLock.Lock()
+	defer si.reserveLock.Unlock()
+	return si.reserved[types.NormalizeAddress(objectId)]
+}
+
+func (si *SuiClient) ReleaseCoins(objectIds ...string) {
+	si.reserveLock.Lock()
+	defer si.reserveLock.Unlock()
+	for _, id := range objectIds {
+		delete(si.reserved, types.NormalizeAddress(id))
+	}
+}
+
+func CoinObjectIds(coins []types.CoinData) []string {
+	objectIds := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		objectIds = append(objectIds, coin.CoinObjectID)
+	}
+	return objectIds
+}
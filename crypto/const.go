@@ -19,5 +19,8 @@ type SigScheme byte
 const (
 	ED25519SigScheme   SigScheme = 0x00
 	Secp256k1SigScheme SigScheme = 0x01
+	Secp256r1SigScheme SigScheme = 0x02
+	MultiSigScheme     SigScheme = 0x03
+	ZkLoginSigScheme   SigScheme = 0x05
 	BLS12381SigScheme  SigScheme = 0xff
 )
@@ -0,0 +1,105 @@
+package crypto
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"golang.org/x/crypto/blake2b"
+)
+
+// multiSigSchemes are the schemes of the CompressedSignature and PublicKey enums, in bcs order
+var multiSigSchemes = []SigScheme{ED25519SigScheme, Secp256k1SigScheme, Secp256r1SigScheme, ZkLoginSigScheme}
+
+// multiSigPublicKeyLengths of the fixed size keys, a zkLogin key is the length-prefixed public identifier
+var multiSigPublicKeyLengths = map[SigScheme]int{ED25519SigScheme: 32, Secp256k1SigScheme: 33, Secp256r1SigScheme: 33}
+
+func readMultiSigScheme(d *bcs.Decoder) (SigScheme, error) {
+	index, err := d.ReadUleb128()
+	if err != nil {
+		return 0, err
+	}
+	if index >= uint64(len(multiSigSchemes)) {
+		return 0, fmt.Errorf("unsupported multisig scheme: %v", index)
+	}
+	return multiSigSchemes[index], nil
+}
+
+// CompressedSignature is a member signature without its public key, the zkLogin one is a serialized zkLogin signature
+type CompressedSignature struct {
+	Scheme    SigScheme
+	Signature []byte
+}
+
+func (cs *CompressedSignature) UnmarshalBCS(d *bcs.Decoder) error {
+	var err error
+	cs.Scheme, err = readMultiSigScheme(d)
+	if err != nil {
+		return err
+	}
+	if cs.Scheme == ZkLoginSigScheme {
+		cs.Signature, err = d.ReadBytes()
+		return err
+	}
+	cs.Signature, err = d.ReadFixedBytes(SignatureLength)
+	return err
+}
+
+type MultiSigMember struct {
+	Scheme    SigScheme
+	PublicKey []byte
+	Weight    uint8
+}
+
+func (mm *MultiSigMember) UnmarshalBCS(d *bcs.Decoder) error {
+	var err error
+	mm.Scheme, err = readMultiSigScheme(d)
+	if err != nil {
+		return err
+	}
+	if mm.Scheme == ZkLoginSigScheme {
+		mm.PublicKey, err = d.ReadBytes()
+	} else {
+		mm.PublicKey, err = d.ReadFixedBytes(multiSigPublicKeyLengths[mm.Scheme])
+	}
+	if err != nil {
+		return err
+	}
+	mm.Weight, err = d.ReadU8()
+	return err
+}
+
+// MultiSigPublicKey is the weighted key set behind a multisig address
+type MultiSigPublicKey struct {
+	Members   []MultiSigMember
+	Threshold uint16
+}
+
+// Address is blake2b256(0x03 || threshold || flag || publicKey || weight ...)
+func (mp *MultiSigPublicKey) Address() string {
+	data := []byte{byte(MultiSigScheme), byte(mp.Threshold), byte(mp.Threshold >> 8)}
+	for _, member := range mp.Members {
+		data = append(data, byte(member.Scheme))
+		data = append(data, member.PublicKey...)
+		data = append(data, member.Weight)
+	}
+	return fmt.Sprintf("0x%x", blake2b.Sum256(data))
+}
+
+// MultiSig is the bcs layout of a multisig signature, Bitmap marks the members in Signatures
+type MultiSig struct {
+	Signatures []CompressedSignature
+	Bitmap     uint16
+	PublicKey  MultiSigPublicKey
+}
+
+// ParseMultiSig decodes a serialized 0x03 || bcs(MultiSig) signature
+func ParseMultiSig(signature []byte) (*MultiSig, error) {
+	if len(signature) == 0 || SigScheme(signature[0]) != MultiSigScheme {
+		return nil, fmt.Errorf("not a multisig signature")
+	}
+	result := &MultiSig{}
+	err := bcs.Unmarshal(signature[1:], result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
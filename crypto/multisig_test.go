@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+// a 2 of (ed25519:1, secp256k1:2, zkLogin:1) multisig signed by the ed25519 member,
+// serialized and hashed by hand from the MultiSig layout
+const (
+	testMultiSig        = "AwEAqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqgEAAwAREREREREREREREREREREREREREREREREREREREREREQEBAiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiIiAgM8G2h0dHBzOi8vYWNjb3VudHMuZ29vZ2xlLmNvbR10fjV5tTUcGGHFV3BBcByVg60p0teCa07UmQIIloVfAQIA"
+	testMultiSigAddress = "0x94103cc8ae3b6ee7048a61fc683f2656854f9e51dc807a3332a44d79aaae3bd3"
+)
+
+func TestParseMultiSig(t *testing.T) {
+	multiSig, err := ParseMultiSig(mustBase64(testMultiSig))
+	if err != nil {
+		panic(err)
+	}
+	if len(multiSig.Signatures) != 1 || multiSig.Signatures[0].Scheme != ED25519SigScheme || multiSig.Bitmap != 1 {
+		t.Fatalf("unexpected signatures: %+v", multiSig)
+	}
+	if !bytes.Equal(multiSig.Signatures[0].Signature, bytes.Repeat([]byte{0xaa}, SignatureLength)) {
+		t.Fatalf("unexpected signature: %x", multiSig.Signatures[0].Signature)
+	}
+	publicKey := multiSig.PublicKey
+	if publicKey.Threshold != 2 || len(publicKey.Members) != 3 {
+		t.Fatalf("unexpected public key: %+v", publicKey)
+	}
+	schemes := []SigScheme{ED25519SigScheme, Secp256k1SigScheme, ZkLoginSigScheme}
+	lengths := []int{32, 33, 1 + len("https://accounts.google.com") + 32}
+	weights := []uint8{1, 2, 1}
+	for i, member := range publicKey.Members {
+		if member.Scheme != schemes[i] || len(member.PublicKey) != lengths[i] || member.Weight != weights[i] {
+			t.Fatalf("unexpected member %v: %+v", i, member)
+		}
+	}
+	if address := publicKey.Address(); address != testMultiSigAddress {
+		t.Fatalf("address %v, expect %v", address, testMultiSigAddress)
+	}
+
+	_, err = ParseMultiSig(mustBase64(testZkLoginSignature))
+	if err == nil {
+		t.Fatalf("expect error for a zkLogin signature")
+	}
+	_, err = ParseMultiSig(append(mustBase64(testMultiSig), 0))
+	if err == nil {
+		t.Fatalf("expect error for trailing bytes")
+	}
+}
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// HttpSigner delegates signing to an external signing service.
+//
+//	POST {endpoint}/public_key  {"key_id":"..."}                  -> {"public_key":"<base64>"}
+//	POST {endpoint}/sign        {"key_id":"...","message":"<b64>"} -> {"signature":"<base64>"}
+//
+// message is the 32-byte intent digest; ecdsa services must sign its sha256 hash,
+// they may answer with a DER or a 64 bytes r||s signature.
+type HttpSigner struct {
+	endpoint  string
+	keyId     string
+	scheme    SigScheme
+	imp       *http.Client
+	lock      sync.Mutex
+	publicKey []byte
+}
+
+func NewHttpSigner(endpoint, keyId string, scheme SigScheme) *HttpSigner {
+	return &HttpSigner{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		keyId:    keyId,
+		scheme:   scheme,
+		imp:      http.DefaultClient,
+	}
+}
+
+func (hs *HttpSigner) SetHttpClient(client *http.Client) {
+	hs.imp = client
+}
+
+func (hs *HttpSigner) SigScheme() SigScheme {
+	return hs.scheme
+}
+
+func (hs *HttpSigner) PublicKeyBytes() ([]byte, error) {
+	hs.lock.Lock()
+	defer hs.lock.Unlock()
+	if hs.publicKey != nil {
+		return hs.publicKey, nil
+	}
+	var result struct {
+		PublicKey string `json:"public_key"`
+	}
+	err := hs.post("/public_key", map[string]string{"key_id": hs.keyId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(result.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	hs.publicKey = publicKey
+	return publicKey, nil
+}
+
+func (hs *HttpSigner) Sign(digest []byte) ([]byte, error) {
+	var result struct {
+		Signature string `json:"signature"`
+	}
+	param := map[string]string{
+		"key_id":  hs.keyId,
+		"message": base64.StdEncoding.EncodeToString(digest),
+	}
+	err := hs.post("/sign", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.Signature)
+}
+
+func (hs *HttpSigner) post(path string, param, value interface{}) error {
+	reqData, err := json.Marshal(param)
+	if err != nil {
+		return err
+	}
+	resp, err := hs.imp.Post(hs.endpoint+path, "application/json", bytes.NewReader(reqData))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("signer response err: %v %v", resp.Status, string(data))
+	}
+	return json.Unmarshal(data, value)
+}
+
+// PKCS#11 mechanisms used for sui signatures
+const (
+	CkmEcdsa uint = 0x00001041
+	CkmEddsa uint = 0x00001057
+)
+
+// Pkcs11Session is the subset of a PKCS#11 session the signer needs,
+// it can be backed by any binding (miekg/pkcs11, vendor sdk, cloud hsm client ...)
+type Pkcs11Session interface {
+	FindPrivateKey(label string) (handle uint, publicKey []byte, err error)
+	Sign(mechanism uint, handle uint, message []byte) ([]byte, error)
+}
+
+type Pkcs11Signer struct {
+	session   Pkcs11Session
+	scheme    SigScheme
+	handle    uint
+	publicKey []byte
+}
+
+func NewPkcs11Signer(session Pkcs11Session, label string, scheme SigScheme) (*Pkcs11Signer, error) {
+	if scheme != ED25519SigScheme && scheme != Secp256k1SigScheme && scheme != Secp256r1SigScheme {
+		return nil, fmt.Errorf("unsupported signature scheme: %v", scheme)
+	}
+	handle, publicKey, err := session.FindPrivateKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return &Pkcs11Signer{
+		session:   session,
+		scheme:    scheme,
+		handle:    handle,
+		publicKey: publicKey,
+	}, nil
+}
+
+func (ps *Pkcs11Signer) SigScheme() SigScheme {
+	return ps.scheme
+}
+
+func (ps *Pkcs11Signer) PublicKeyBytes() ([]byte, error) {
+	return ps.publicKey, nil
+}
+
+func (ps *Pkcs11Signer) Sign(digest []byte) ([]byte, error) {
+	mechanism := CkmEddsa
+	if ps.scheme != ED25519SigScheme {
+		// CKM_ECDSA signs the given hash as is
+		mechanism = CkmEcdsa
+	}
+	return ps.session.Sign(mechanism, ps.handle, prehash(ps.scheme, digest))
+}
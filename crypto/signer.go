@@ -0,0 +1,155 @@
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"golang.org/x/crypto/blake2b"
+	"math/big"
+)
+
+// Signer produces raw signatures over the 32-byte intent digest of a message.
+// The key material may live anywhere (process memory, KMS, HSM, remote service).
+type Signer interface {
+	SigScheme() SigScheme
+	PublicKeyBytes() ([]byte, error)
+	Sign(digest []byte) ([]byte, error)
+}
+
+var secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+func (kp *KeyPair) SigScheme() SigScheme {
+	return ED25519SigScheme
+}
+
+func (kp *KeyPair) PublicKeyBytes() ([]byte, error) {
+	return kp.PublicKey, nil
+}
+
+// SignerAddress is the sui address of signer: blake2b256(flag || publicKey)
+func SignerAddress(signer Signer) (string, error) {
+	publicKey, err := signer.PublicKeyBytes()
+	if err != nil {
+		return "", err
+	}
+	data := make([]byte, 0, 1+len(publicKey))
+	data = append(data, byte(signer.SigScheme()))
+	data = append(data, publicKey...)
+	return fmt.Sprintf("0x%x", blake2b.Sum256(data)), nil
+}
+
+// SignatureAddress returns the signer address of a serialized signature: flag || signature || publicKey,
+// a multisig address from its public key set or a zkLogin address from its iss claim and address seed.
+// The signature itself is not verified.
+func SignatureAddress(signature []byte) (string, error) {
+	if len(signature) == 0 {
+		return "", fmt.Errorf("empty signature")
+	}
+	var publicKeyLength int
+	switch SigScheme(signature[0]) {
+	case ED25519SigScheme:
+		publicKeyLength = 32
+	case Secp256k1SigScheme, Secp256r1SigScheme:
+		publicKeyLength = 33
+	case MultiSigScheme:
+		multiSig, err := ParseMultiSig(signature)
+		if err != nil {
+			return "", err
+		}
+		return multiSig.PublicKey.Address(), nil
+	case ZkLoginSigScheme:
+		zkLogin, err := ParseZkLoginSignature(signature)
+		if err != nil {
+			return "", err
+		}
+		return zkLogin.Address()
+	default:
+		return "", fmt.Errorf("unsupported signature scheme: %v", signature[0])
+	}
+	if len(signature) != 1+SignatureLength+publicKeyLength {
+		return "", fmt.Errorf("invalid signature length: %v", len(signature))
+	}
+	data := make([]byte, 0, 1+publicKeyLength)
+	data = append(data, signature[0])
+	data = append(data, signature[1+SignatureLength:]...)
+	return fmt.Sprintf("0x%x", blake2b.Sum256(data)), nil
+}
+
+// SerializeSignature wraps a raw signature into sui format: flag || signature || publicKey
+func SerializeSignature(scheme SigScheme, signature, publicKey []byte) ([]byte, error) {
+	if len(signature) != SignatureLength {
+		return nil, fmt.Errorf("invalid signature length: %v", len(signature))
+	}
+	data := make([]byte, 0, 1+len(signature)+len(publicKey))
+	data = append(data, byte(scheme))
+	data = append(data, signature...)
+	data = append(data, publicKey...)
+	return data, nil
+}
+
+// SignDigest signs the intent digest with signer and returns the serialized signature
+func SignDigest(signer Signer, digest []byte) ([]byte, error) {
+	signature, err := signer.Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	signature, err = NormalizeSignature(signer.SigScheme(), signature)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := signer.PublicKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return SerializeSignature(signer.SigScheme(), signature, publicKey)
+}
+
+// NormalizeSignature converts ecdsa signatures (DER or r||s) into the 64 bytes low-s form sui expects
+func NormalizeSignature(scheme SigScheme, signature []byte) ([]byte, error) {
+	var n *big.Int
+	switch scheme {
+	case ED25519SigScheme:
+		return signature, nil
+	case Secp256k1SigScheme:
+		n = secp256k1N
+	case Secp256r1SigScheme:
+		n = elliptic.P256().Params().N
+	default:
+		return nil, fmt.Errorf("unsupported signature scheme: %v", scheme)
+	}
+	var r, s *big.Int
+	if len(signature) == SignatureLength {
+		r = new(big.Int).SetBytes(signature[:32])
+		s = new(big.Int).SetBytes(signature[32:])
+	} else {
+		var der struct {
+			R, S *big.Int
+		}
+		rest, err := asn1.Unmarshal(signature, &der)
+		if err != nil {
+			return nil, fmt.Errorf("parse der signature error: %v", err)
+		}
+		if len(rest) != 0 {
+			return nil, fmt.Errorf("trailing data after der signature")
+		}
+		r, s = der.R, der.S
+	}
+	halfN := new(big.Int).Rsh(n, 1)
+	if s.Cmp(halfN) > 0 {
+		s = new(big.Int).Sub(n, s)
+	}
+	result := make([]byte, SignatureLength)
+	r.FillBytes(result[:32])
+	s.FillBytes(result[32:])
+	return result, nil
+}
+
+// prehash returns the message an ecdsa key has to sign for the given digest
+func prehash(scheme SigScheme, digest []byte) []byte {
+	if scheme == Secp256k1SigScheme || scheme == Secp256r1SigScheme {
+		hash := sha256.Sum256(digest)
+		return hash[:]
+	}
+	return digest
+}
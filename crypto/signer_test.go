@@ -0,0 +1,286 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"golang.org/x/crypto/blake2b"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestKeyPair() *KeyPair {
+	seed, err := hex.DecodeString("0efd0145c9854b3189b20201e93b0fa91bd68b95936363f172846150fca902d7")
+	if err != nil {
+		panic(err)
+	}
+	keyPair, err := NewKeyPairFromSeed(seed)
+	if err != nil {
+		panic(err)
+	}
+	return keyPair
+}
+
+func TestHttpSigner(t *testing.T) {
+	keyPair := newTestKeyPair()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req["key_id"] != "test-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/public_key":
+			json.NewEncoder(w).Encode(map[string]string{"public_key": base64.StdEncoding.EncodeToString(keyPair.PublicKey)})
+		case "/sign":
+			message, _ := base64.StdEncoding.DecodeString(req["message"])
+			signature := ed25519.Sign(keyPair.PrivateKey, message)
+			json.NewEncoder(w).Encode(map[string]string{"signature": base64.StdEncoding.EncodeToString(signature)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	signer := NewHttpSigner(server.URL, "test-key", ED25519SigScheme)
+	digest := blake2b.Sum256([]byte("wo he ni"))
+	remote, err := SignDigest(signer, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	local, err := SignDigest(keyPair, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	if hex.EncodeToString(remote) != hex.EncodeToString(local) {
+		t.Fatalf("remote signature %x not match local %x", remote, local)
+	}
+}
+
+func TestHttpSigner_Secp256r1(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	publicKey := elliptic.MarshalCompressed(elliptic.P256(), privateKey.X, privateKey.Y)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/public_key":
+			json.NewEncoder(w).Encode(map[string]string{"public_key": base64.StdEncoding.EncodeToString(publicKey)})
+		case "/sign":
+			message, _ := base64.StdEncoding.DecodeString(req["message"])
+			// like a kms, hash the message with sha256 and answer with DER
+			hash := sha256.Sum256(message)
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"signature": base64.StdEncoding.EncodeToString(signature)})
+		}
+	}))
+	defer server.Close()
+
+	signer := NewHttpSigner(server.URL, "test-key", Secp256r1SigScheme)
+	digest := blake2b.Sum256([]byte("wo he ni"))
+	signatureData, err := SignDigest(signer, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	if len(signatureData) != 1+SignatureLength+len(publicKey) || signatureData[0] != byte(Secp256r1SigScheme) {
+		t.Fatalf("unexpected signature: %x", signatureData)
+	}
+	r := new(big.Int).SetBytes(signatureData[1:33])
+	s := new(big.Int).SetBytes(signatureData[33:65])
+	if s.Cmp(new(big.Int).Rsh(elliptic.P256().Params().N, 1)) > 0 {
+		t.Fatalf("signature s is not low: %x", s)
+	}
+	if !ecdsa.Verify(&privateKey.PublicKey, prehash(Secp256r1SigScheme, digest[:]), r, s) {
+		t.Fatalf("verify signature fail")
+	}
+}
+
+func TestHttpSigner_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("key disabled"))
+	}))
+	defer server.Close()
+
+	signer := NewHttpSigner(server.URL, "test-key", ED25519SigScheme)
+	_, err := signer.PublicKeyBytes()
+	if err == nil {
+		t.Fatalf("expect error for forbidden response")
+	}
+	digest := blake2b.Sum256([]byte("wo he ni"))
+	_, err = SignDigest(signer, digest[:])
+	if err == nil {
+		t.Fatalf("expect error for forbidden response")
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	r := new(big.Int).SetBytes(bytes.Repeat([]byte{0x11}, 32))
+	lowS := big.NewInt(12345)
+	encode := func(r, s *big.Int) []byte {
+		result := make([]byte, SignatureLength)
+		r.FillBytes(result[:32])
+		s.FillBytes(result[32:])
+		return result
+	}
+	der := func(r, s *big.Int) []byte {
+		data, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		if err != nil {
+			panic(err)
+		}
+		return data
+	}
+	cases := []struct {
+		name      string
+		scheme    SigScheme
+		signature []byte
+		expect    []byte
+	}{
+		{"k1 raw low s", Secp256k1SigScheme, encode(r, lowS), encode(r, lowS)},
+		{"k1 raw high s", Secp256k1SigScheme, encode(r, new(big.Int).Sub(secp256k1N, lowS)), encode(r, lowS)},
+		{"k1 der high s", Secp256k1SigScheme, der(r, new(big.Int).Sub(secp256k1N, lowS)), encode(r, lowS)},
+		{"r1 der low s", Secp256r1SigScheme, der(r, lowS), encode(r, lowS)},
+		{"r1 raw high s", Secp256r1SigScheme, encode(r, new(big.Int).Sub(elliptic.P256().Params().N, lowS)), encode(r, lowS)},
+		{"r1 der high s", Secp256r1SigScheme, der(r, new(big.Int).Sub(elliptic.P256().Params().N, lowS)), encode(r, lowS)},
+		{"ed25519 as is", ED25519SigScheme, encode(r, lowS), encode(r, lowS)},
+	}
+	for _, c := range cases {
+		result, err := NormalizeSignature(c.scheme, c.signature)
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if !bytes.Equal(result, c.expect) {
+			t.Fatalf("%v: got %x, expect %x", c.name, result, c.expect)
+		}
+	}
+
+	for name, signature := range map[string][]byte{
+		"bad der":  {0x30, 0x01},
+		"trailing": append(der(r, lowS), 0x00),
+		"short":    encode(r, lowS)[:63],
+	} {
+		_, err := NormalizeSignature(Secp256k1SigScheme, signature)
+		if err == nil {
+			t.Fatalf("%v: expect error", name)
+		}
+	}
+	_, err := NormalizeSignature(MultiSigScheme, encode(r, lowS))
+	if err == nil {
+		t.Fatalf("expect error for multisig scheme")
+	}
+}
+
+type testPkcs11Session struct {
+	keyPair *KeyPair
+}
+
+func (s *testPkcs11Session) FindPrivateKey(label string) (uint, []byte, error) {
+	return 1, s.keyPair.PublicKey, nil
+}
+
+func (s *testPkcs11Session) Sign(mechanism uint, handle uint, message []byte) ([]byte, error) {
+	return ed25519.Sign(s.keyPair.PrivateKey, message), nil
+}
+
+func TestPkcs11Signer(t *testing.T) {
+	keyPair := newTestKeyPair()
+	signer, err := NewPkcs11Signer(&testPkcs11Session{keyPair: keyPair}, "sui", ED25519SigScheme)
+	if err != nil {
+		panic(err)
+	}
+	digest := blake2b.Sum256([]byte("wo he ni"))
+	signatureData, err := SignDigest(signer, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	if signatureData[0] != byte(ED25519SigScheme) {
+		t.Fatalf("unexpected flag: %v", signatureData[0])
+	}
+	if !keyPair.Verify(digest[:], signatureData[1:1+SignatureLength]) {
+		t.Fatalf("verify signature fail")
+	}
+}
+
+func TestSignatureAddress(t *testing.T) {
+	serialize := func(scheme SigScheme, publicKey []byte) []byte {
+		data, err := SerializeSignature(scheme, bytes.Repeat([]byte{0xaa}, SignatureLength), publicKey)
+		if err != nil {
+			panic(err)
+		}
+		return data
+	}
+	cases := []struct {
+		name      string
+		signature []byte
+		expect    string
+	}{
+		{"ed25519", serialize(ED25519SigScheme, bytes.Repeat([]byte{0x11}, 32)), "0x0a0cb64ee9932145bb2398a0964c964dc89f917b6b8d5809f3b1487c80cf827d"},
+		{"secp256k1", serialize(Secp256k1SigScheme, append([]byte{0x02}, bytes.Repeat([]byte{0x22}, 32)...)), "0x11a2e96c61805e0aa6792b041eae1fbd26ba0bdfecf5786109245ccaf5b94650"},
+		{"secp256r1", serialize(Secp256r1SigScheme, append([]byte{0x03}, bytes.Repeat([]byte{0x33}, 32)...)), "0x58ea0d5975405fda7c102b19f1f9f12dec97718766dd7b0bc64f8d466cb60941"},
+		{"multisig", mustBase64(testMultiSig), testMultiSigAddress},
+		{"zklogin", mustBase64(testZkLoginSignature), "0xf7badc2b245c7f74d7509a4aa357ecf80a29e7713fb4c44b0e7541ec43885ee1"},
+	}
+	for _, c := range cases {
+		address, err := SignatureAddress(c.signature)
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if address != c.expect {
+			t.Fatalf("%v: address %v, expect %v", c.name, address, c.expect)
+		}
+	}
+
+	keyPair := newTestKeyPair()
+	digest := blake2b.Sum256([]byte("wo he ni"))
+	signature, err := SignDigest(keyPair, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	address, err := SignatureAddress(signature)
+	if err != nil {
+		panic(err)
+	}
+	if expect, _ := SignerAddress(keyPair); address != expect {
+		t.Fatalf("address %v not match signer %v", address, expect)
+	}
+
+	for name, signature := range map[string][]byte{
+		"empty":           nil,
+		"unknown scheme":  {0x04, 0x00},
+		"ed25519 short":   serialize(ED25519SigScheme, bytes.Repeat([]byte{0x11}, 31)),
+		"secp256k1 as 32": serialize(Secp256k1SigScheme, bytes.Repeat([]byte{0x22}, 32)),
+		"secp256r1 long":  serialize(Secp256r1SigScheme, bytes.Repeat([]byte{0x33}, 34)),
+		"multisig cut":    mustBase64(testMultiSig)[:40],
+		"zklogin cut":     mustBase64(testZkLoginSignature)[:40],
+	} {
+		_, err := SignatureAddress(signature)
+		if err == nil {
+			t.Fatalf("%v: expect error", name)
+		}
+	}
+}
+
+func mustBase64(value string) []byte {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"golang.org/x/crypto/blake2b"
+	"math/big"
+	"strings"
+)
+
+const googleIss = "accounts.google.com"
+
+// bn254FieldModulus bounds the address seed, it is a bn254 scalar field element
+var bn254FieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+type ZkLoginProofPoints struct {
+	A []string   `json:"a"`
+	B [][]string `json:"b"`
+	C []string   `json:"c"`
+}
+
+type ZkLoginClaim struct {
+	Value     string `json:"value"`
+	IndexMod4 uint8  `json:"indexMod4"`
+}
+
+// ZkLoginInputs is the prover response, field order matches the bcs layout
+type ZkLoginInputs struct {
+	ProofPoints      ZkLoginProofPoints `json:"proofPoints"`
+	IssBase64Details ZkLoginClaim       `json:"issBase64Details"`
+	HeaderBase64     string             `json:"headerBase64"`
+	AddressSeed      string             `json:"addressSeed"`
+}
+
+type ZkLoginSignature struct {
+	Inputs   ZkLoginInputs
+	MaxEpoch uint64
+	// UserSignature is the serialized signature of the ephemeral key: flag || signature || publicKey
+	UserSignature []byte
+}
+
+func NewZkLoginSignature(inputs ZkLoginInputs, maxEpoch uint64, userSignature []byte) *ZkLoginSignature {
+	return &ZkLoginSignature{
+		Inputs:        inputs,
+		MaxEpoch:      maxEpoch,
+		UserSignature: userSignature,
+	}
+}
+
+// Serialize returns 0x05 || bcs(ZkLoginSignature), the format accepted by ExecuteTransactionBlock
+func (zs *ZkLoginSignature) Serialize() ([]byte, error) {
+	data, err := bcs.Marshal(zs)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(ZkLoginSigScheme)}, data...), nil
+}
+
+// ParseZkLoginSignature decodes a serialized 0x05 || bcs(ZkLoginSignature) signature
+func ParseZkLoginSignature(signature []byte) (*ZkLoginSignature, error) {
+	if len(signature) == 0 || SigScheme(signature[0]) != ZkLoginSigScheme {
+		return nil, fmt.Errorf("not a zkLogin signature")
+	}
+	result := &ZkLoginSignature{}
+	err := bcs.Unmarshal(signature[1:], result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Iss is the issuer claim carried by the signature
+func (zs *ZkLoginSignature) Iss() (string, error) {
+	claim, err := decodeClaim(zs.Inputs.IssBase64Details)
+	if err != nil {
+		return "", err
+	}
+	// the claim is the `"iss":"...",` slice of the jwt payload, ending with ',' or '}'
+	if !strings.HasSuffix(claim, ",") && !strings.HasSuffix(claim, "}") {
+		return "", fmt.Errorf("invalid iss claim: %v", claim)
+	}
+	var value map[string]string
+	err = json.Unmarshal([]byte("{"+claim[:len(claim)-1]+"}"), &value)
+	if err != nil {
+		return "", fmt.Errorf("invalid iss claim %v: %v", claim, err)
+	}
+	iss, ok := value["iss"]
+	if !ok || len(value) != 1 {
+		return "", fmt.Errorf("invalid iss claim: %v", claim)
+	}
+	return iss, nil
+}
+
+// Address is the zkLogin address of the signer, from the iss claim and the address seed
+func (zs *ZkLoginSignature) Address() (string, error) {
+	iss, err := zs.Iss()
+	if err != nil {
+		return "", err
+	}
+	return ZkLoginAddress(iss, zs.Inputs.AddressSeed)
+}
+
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// decodeClaim decodes a base64url slice of the jwt payload, IndexMod4 is the position of its
+// first character in the payload modulo 4, which tells the bits that belong to the bytes around it
+func decodeClaim(claim ZkLoginClaim) (string, error) {
+	if len(claim.Value) < 2 {
+		return "", fmt.Errorf("invalid claim: %v", claim.Value)
+	}
+	var bits []byte
+	for _, c := range []byte(claim.Value) {
+		index := strings.IndexByte(base64URLAlphabet, c)
+		if index < 0 {
+			return "", fmt.Errorf("invalid base64url claim: %v", claim.Value)
+		}
+		for shift := 5; shift >= 0; shift-- {
+			bits = append(bits, byte(index>>shift)&1)
+		}
+	}
+	first := int(claim.IndexMod4) % 4
+	last := (int(claim.IndexMod4) + len(claim.Value) - 1) % 4
+	if first == 3 || last == 0 {
+		return "", fmt.Errorf("invalid claim offset: %v", claim.IndexMod4)
+	}
+	bits = bits[2*first : len(bits)-2*(3-last)]
+	if len(bits)%8 != 0 {
+		return "", fmt.Errorf("invalid claim length: %v", claim.Value)
+	}
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		data[i/8] |= bit << (7 - i%8)
+	}
+	return string(data), nil
+}
+
+// ZkLoginAddress derives the address from the issuer and the address seed.
+// The seed is not computed here: it is the decimal Poseidon hash of (kc_name, kc_value, aud, Poseidon(salt)),
+// the caller must supply it, e.g. ZkLoginInputs.AddressSeed from the prover or the salt service.
+func ZkLoginAddress(iss, addressSeed string) (string, error) {
+	seed, ok := new(big.Int).SetString(addressSeed, 10)
+	if !ok || seed.Sign() < 0 || seed.Cmp(bn254FieldModulus) >= 0 {
+		return "", fmt.Errorf("invalid address seed: %v", addressSeed)
+	}
+	if iss == googleIss {
+		iss = "https://" + googleIss
+	}
+	if len(iss) > 255 {
+		return "", fmt.Errorf("iss too long: %v", len(iss))
+	}
+	seedBytes := make([]byte, 32)
+	seed.FillBytes(seedBytes)
+	data := make([]byte, 0)
+	data = append(data, byte(ZkLoginSigScheme), byte(len(iss)))
+	data = append(data, []byte(iss)...)
+	data = append(data, seedBytes...)
+	hash := blake2b.Sum256(data)
+	return fmt.Sprintf("0x%x", hash), nil
+}
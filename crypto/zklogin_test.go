@@ -0,0 +1,114 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZkLoginAddress(t *testing.T) {
+	seed := "13322897930163218532266430409510394316985274769125667290600321564259466511711"
+	cases := []struct {
+		iss    string
+		seed   string
+		expect string
+	}{
+		{"https://accounts.google.com", seed, "0xf7badc2b245c7f74d7509a4aa357ecf80a29e7713fb4c44b0e7541ec43885ee1"},
+		// google tokens may omit the scheme
+		{"accounts.google.com", seed, "0xf7badc2b245c7f74d7509a4aa357ecf80a29e7713fb4c44b0e7541ec43885ee1"},
+		{"https://id.twitch.tv/oauth2", "380704556853533152350240698167704405529973457670972223618755249929828551006", "0x74e4af1698cb67c2e5850b5bd2a1a0a8283b23295b583e6e45305841f6612be1"},
+		{"https://accounts.google.com", "0", "0x089ef22e71af402ed76c56feef77bd283beb700a2f9878256b03fa499540f329"},
+	}
+	for _, c := range cases {
+		address, err := ZkLoginAddress(c.iss, c.seed)
+		if err != nil {
+			t.Fatalf("%v %v: %v", c.iss, c.seed, err)
+		}
+		if address != c.expect {
+			t.Fatalf("%v %v: address %v not match %v", c.iss, c.seed, address, c.expect)
+		}
+	}
+
+	for _, invalid := range []string{"", "-1", "0x12", "21888242871839275222246405745257275088548364400416034343698204186575808495617"} {
+		_, err := ZkLoginAddress("https://accounts.google.com", invalid)
+		if err == nil {
+			t.Fatalf("expect error for address seed %v", invalid)
+		}
+	}
+	_, err := ZkLoginAddress(strings.Repeat("a", 256), seed)
+	if err == nil {
+		t.Fatalf("expect error for long iss")
+	}
+}
+
+func TestZkLoginSignature_Serialize(t *testing.T) {
+	inputs := ZkLoginInputs{
+		ProofPoints: ZkLoginProofPoints{
+			A: []string{"1", "2"},
+			B: [][]string{{"3"}},
+			C: []string{"4"},
+		},
+		IssBase64Details: ZkLoginClaim{Value: "iss", IndexMod4: 1},
+		HeaderBase64:     "h",
+		AddressSeed:      "5",
+	}
+	data, err := NewZkLoginSignature(inputs, 10, []byte{0, 1}).Serialize()
+	if err != nil {
+		panic(err)
+	}
+	expect := []byte{5, 2, 1, '1', 1, '2', 1, 1, 1, '3', 1, 1, '4', 3, 'i', 's', 's', 1, 1, 'h', 1, '5', 10, 0, 0, 0, 0, 0, 0, 0, 2, 0, 1}
+	if string(data) != string(expect) {
+		t.Fatalf("serialize %v not match %v", data, expect)
+	}
+}
+
+// signs with an ed25519 ephemeral key for https://accounts.google.com, serialized by hand
+const testZkLoginSignature = "BQIBMQEyAQEBMwEBNDF5SnBjM01pT2lKb2RIUndjem92TDJGalkyOTFiblJ6TG1kdmIyZHNaUzVqYjIwaUxDAQFoTTEzMzIyODk3OTMwMTYzMjE4NTMyMjY2NDMwNDA5NTEwMzk0MzE2OTg1Mjc0NzY5MTI1NjY3MjkwNjAwMzIxNTY0MjU5NDY2NTExNzExCgAAAAAAAABhAKqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqoREREREREREREREREREREREREREREREREREREREREREQ=="
+
+func TestParseZkLoginSignature(t *testing.T) {
+	signature, err := ParseZkLoginSignature(mustBase64(testZkLoginSignature))
+	if err != nil {
+		panic(err)
+	}
+	if signature.MaxEpoch != 10 || signature.Inputs.HeaderBase64 != "h" || len(signature.UserSignature) != 1+SignatureLength+32 {
+		t.Fatalf("unexpected signature: %+v", signature)
+	}
+	address, err := signature.Address()
+	if err != nil {
+		panic(err)
+	}
+	if address != "0xf7badc2b245c7f74d7509a4aa357ecf80a29e7713fb4c44b0e7541ec43885ee1" {
+		t.Fatalf("unexpected address: %v", address)
+	}
+}
+
+func TestZkLoginSignature_Iss(t *testing.T) {
+	// the iss claim cut out of payloads where it starts at each offset modulo 4
+	for _, claim := range []ZkLoginClaim{
+		{Value: "yJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLC", IndexMod4: 1},
+		{Value: "wiaXNzIjoiaHR0cHM6Ly9hY2NvdW50cy5nb29nbGUuY29tIiw", IndexMod4: 2},
+		{Value: "ImlzcyI6Imh0dHBzOi8vYWNjb3VudHMuZ29vZ2xlLmNvbSIs", IndexMod4: 0},
+	} {
+		signature := &ZkLoginSignature{Inputs: ZkLoginInputs{IssBase64Details: claim}}
+		iss, err := signature.Iss()
+		if err != nil {
+			t.Fatalf("%+v: %v", claim, err)
+		}
+		if iss != "https://accounts.google.com" {
+			t.Fatalf("%+v: unexpected iss %v", claim, iss)
+		}
+	}
+
+	for _, claim := range []ZkLoginClaim{
+		{Value: "ImlzcyI6Imh0dHBzOi8vYWNjb3VudHMuZ29vZ2xlLmNvbSIs", IndexMod4: 3},
+		{Value: "ImlzcyI6Imh0dHBzOi8vYWNjb3VudHMuZ29vZ2xlLmNvbSIs", IndexMod4: 1},
+		{Value: "ImF1ZCI6IngiLC", IndexMod4: 0},
+		{Value: "I!lzcyI6Imh0dHBzOi8vYWNjb3VudHMuZ29vZ2xlLmNvbSIs", IndexMod4: 0},
+		{Value: "I", IndexMod4: 0},
+	} {
+		signature := &ZkLoginSignature{Inputs: ZkLoginInputs{IssBase64Details: claim}}
+		_, err := signature.Iss()
+		if err == nil {
+			t.Fatalf("%+v: expect error", claim)
+		}
+	}
+}
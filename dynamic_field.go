@@ -0,0 +1,84 @@
+package go_sui_sdk
+
+import (
+	"github.com/ltp456/go-sui-sdk/types"
+)
+
+// GetDynamicFields returns one page of the dynamic fields of parentId, limit 0 uses the node default
+func (si *SuiClient) GetDynamicFields(parentId, cursor string, limit uint64) (*types.DynamicFieldPage, error) {
+	result := &types.DynamicFieldPage{}
+	params := Params{}
+	params.AddValue(parentId)
+	params.AddValue(cursorParam(cursor))
+	params.AddValue(limitParam(limit))
+	err := si.post("suix_getDynamicFields", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDynamicFieldObject returns DynamicFieldNotFoundError when parentId has no field name
+func (si *SuiClient) GetDynamicFieldObject(parentId string, name types.DynamicFieldName) (*types.ObjData, error) {
+	result := &types.ObjData{}
+	params := Params{}
+	params.AddValue(parentId)
+	params.AddValue(name)
+	err := si.post("suix_getDynamicFieldObject", params, result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil && result.Error.Code != types.DisplayErrorCode {
+		return nil, result.Error.Err()
+	}
+	return result, nil
+}
+
+// GetDynamicFieldValue decodes the value stored under name into value, see types.Object.DecodeDynamicFieldValue
+func (si *SuiClient) GetDynamicFieldValue(parentId string, name types.DynamicFieldName, value interface{}) error {
+	object, err := si.GetDynamicFieldObject(parentId, name)
+	if err != nil {
+		return err
+	}
+	return object.Data.DecodeDynamicFieldValue(value)
+}
+
+// GetTableValue reads table[key] of a Table<K, V> or ObjectTable<K, V>, keyType is the move type of K
+func (si *SuiClient) GetTableValue(table types.Table, keyType string, key interface{}, value interface{}) error {
+	return si.GetDynamicFieldValue(table.ID.ID.String(), types.DynamicFieldName{Type: keyType, Value: key}, value)
+}
+
+// GetBagValue reads bag[key] of a Bag or ObjectBag, keyType is the move type of the key
+func (si *SuiClient) GetBagValue(bag types.Bag, keyType string, key interface{}, value interface{}) error {
+	return si.GetDynamicFieldValue(bag.ID.ID.String(), types.DynamicFieldName{Type: keyType, Value: key}, value)
+}
+
+type DynamicFieldIterator struct {
+	pager  *Pager[types.DynamicFieldInfo]
+	cursor string
+}
+
+func (si *SuiClient) NewDynamicFieldIterator(parentId string, limit uint64) *DynamicFieldIterator {
+	it := &DynamicFieldIterator{}
+	it.pager = NewPager(func() ([]types.DynamicFieldInfo, bool, error) {
+		page, err := si.GetDynamicFields(parentId, it.cursor, limit)
+		if err != nil {
+			return nil, false, err
+		}
+		it.cursor = page.NextCursor
+		return page.Data, page.HasNextPage, nil
+	})
+	return it
+}
+
+func (it *DynamicFieldIterator) Next() bool {
+	return it.pager.Next()
+}
+
+func (it *DynamicFieldIterator) Value() *types.DynamicFieldInfo {
+	return it.pager.Value()
+}
+
+func (it *DynamicFieldIterator) Err() error {
+	return it.pager.Err()
+}
@@ -0,0 +1,259 @@
+package go_sui_sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/crypto"
+	"github.com/ltp456/go-sui-sdk/types"
+	"time"
+)
+
+const EnvelopeVersion = 1
+
+// TransactionSummary is what the offline signer reviews before signing, it is derived from the tx bytes
+type TransactionSummary struct {
+	Digest          string   `json:"digest"`
+	Sender          string   `json:"sender"`
+	GasOwner        string   `json:"gasOwner"`
+	GasPrice        string   `json:"gasPrice"`
+	GasBudget       string   `json:"gasBudget"`
+	GasPayment      []string `json:"gasPayment"`
+	InputObjects    []string `json:"inputObjects"`
+	Commands        []string `json:"commands"`
+	ExpirationEpoch *uint64  `json:"expirationEpoch,omitempty"`
+}
+
+func NewTransactionSummary(txData *types.TransactionData) (TransactionSummary, error) {
+	digest, err := txData.Digest()
+	if err != nil {
+		return TransactionSummary{}, err
+	}
+	summary := TransactionSummary{
+		Digest:          digest,
+		Sender:          types.NormalizeAddress(txData.Sender.String()),
+		GasOwner:        types.NormalizeAddress(txData.GasData.Owner),
+		GasPrice:        txData.GasData.Price,
+		GasBudget:       txData.GasData.Budget,
+		ExpirationEpoch: txData.Expiration.Epoch,
+	}
+	for _, payment := range txData.GasData.Payment {
+		summary.GasPayment = append(summary.GasPayment, types.NormalizeAddress(payment.ObjectID))
+	}
+	for _, objectId := range txData.Kind.InputObjectIDs() {
+		summary.InputObjects = append(summary.InputObjects, types.NormalizeAddress(objectId))
+	}
+	for _, command := range txData.Kind.Commands {
+		if command.MoveCall != nil {
+			target := fmt.Sprintf("%v::%v::%v", types.NormalizeAddress(command.MoveCall.Package), command.MoveCall.Module, command.MoveCall.Function)
+			summary.Commands = append(summary.Commands, fmt.Sprintf("%v %v", command.Kind(), target))
+			continue
+		}
+		summary.Commands = append(summary.Commands, command.Kind().String())
+	}
+	return summary, nil
+}
+
+// Envelope carries an unsigned transaction to an offline signer and the signatures back, as json
+type Envelope struct {
+	Version         int                `json:"version"`
+	ChainIdentifier string             `json:"chainIdentifier"`
+	TxBytes         string             `json:"txBytes"`
+	Summary         TransactionSummary `json:"summary"`
+	Signers         []string           `json:"signers"`
+	// ExpiresAt stops signing and submitting a stale envelope, nil for no expiry
+	ExpiresAt  *time.Time        `json:"expiresAt,omitempty"`
+	Signatures map[string]string `json:"signatures"`
+}
+
+func (si *SuiClient) GetChainIdentifier() (string, error) {
+	var result string
+	err := si.post("sui_getChainIdentifier", nil, &result)
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// NewEnvelope wraps unsignedTx on the online machine, ttl 0 means the envelope does not expire
+func (si *SuiClient) NewEnvelope(unsignedTx *types.UnsignedTx, ttl time.Duration) (*Envelope, error) {
+	chainIdentifier, err := si.GetChainIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	txData, err := types.ParseTransactionData(unsignedTx.TxBytes)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := NewTransactionSummary(txData)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		Version:         EnvelopeVersion,
+		ChainIdentifier: chainIdentifier,
+		TxBytes:         unsignedTx.TxBytes,
+		Summary:         summary,
+		Signers:         txData.Signers(),
+		Signatures:      map[string]string{},
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl).UTC()
+		envelope.ExpiresAt = &expiresAt
+	}
+	return envelope, nil
+}
+
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	envelope := &Envelope{}
+	err := json.Unmarshal(data, envelope)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.Version != EnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version: %v", envelope.Version)
+	}
+	if envelope.Signatures == nil {
+		envelope.Signatures = map[string]string{}
+	}
+	return envelope, nil
+}
+
+func (e *Envelope) Marshal() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+func (e *Envelope) IsExpired() bool {
+	return e.ExpiresAt != nil && time.Now().After(*e.ExpiresAt)
+}
+
+// Verify checks the summary and signers describe the tx bytes, so a tampered envelope is not signed
+func (e *Envelope) Verify() error {
+	txData, err := types.ParseTransactionData(e.TxBytes)
+	if err != nil {
+		return err
+	}
+	summary, err := NewTransactionSummary(txData)
+	if err != nil {
+		return err
+	}
+	if summary.Digest != e.Summary.Digest {
+		return fmt.Errorf("envelope summary digest %v not match tx bytes %v", e.Summary.Digest, summary.Digest)
+	}
+	expect, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	actual, err := json.Marshal(e.Summary)
+	if err != nil {
+		return err
+	}
+	if string(expect) != string(actual) {
+		return fmt.Errorf("envelope summary not match tx bytes")
+	}
+	signers := txData.Signers()
+	if fmt.Sprint(signers) != fmt.Sprint(e.Signers) {
+		return fmt.Errorf("envelope signers %v not match tx bytes %v", e.Signers, signers)
+	}
+	return nil
+}
+
+func (e *Envelope) isSigner(address string) bool {
+	for _, signer := range e.Signers {
+		if signer == types.NormalizeAddress(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign signs envelope on the offline machine, signer must be one of the envelope signers
+func Sign(envelope *Envelope, signer crypto.Signer) error {
+	if envelope.IsExpired() {
+		return fmt.Errorf("envelope expired at %v", envelope.ExpiresAt)
+	}
+	err := envelope.Verify()
+	if err != nil {
+		return err
+	}
+	address, err := crypto.SignerAddress(signer)
+	if err != nil {
+		return err
+	}
+	if !envelope.isSigner(address) {
+		return fmt.Errorf("%v is not a signer of %v", address, envelope.Signers)
+	}
+	signature, err := SignTransaction(signer, envelope.TxBytes)
+	if err != nil {
+		return err
+	}
+	if envelope.Signatures == nil {
+		envelope.Signatures = map[string]string{}
+	}
+	envelope.Signatures[types.NormalizeAddress(address)] = signature
+	return nil
+}
+
+// AddSignature adds a signature made elsewhere, e.g. a multisig or zkLogin signature of signer.
+// The address behind the signature must be signer, the signature itself is verified by the node.
+func (e *Envelope) AddSignature(signer, signature string) error {
+	if !e.isSigner(signer) {
+		return fmt.Errorf("%v is not a signer of %v", signer, e.Signers)
+	}
+	err := checkSignatureAddress(signer, signature)
+	if err != nil {
+		return err
+	}
+	if e.Signatures == nil {
+		e.Signatures = map[string]string{}
+	}
+	e.Signatures[types.NormalizeAddress(signer)] = signature
+	return nil
+}
+
+func checkSignatureAddress(signer, signature string) error {
+	signatureData, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature %v: %v", signature, err)
+	}
+	address, err := crypto.SignatureAddress(signatureData)
+	if err != nil {
+		return err
+	}
+	if types.NormalizeAddress(address) != types.NormalizeAddress(signer) {
+		return fmt.Errorf("signature is made by %v, not %v", address, signer)
+	}
+	return nil
+}
+
+// Submit broadcasts a fully signed envelope from the online machine
+func (si *SuiClient) Submit(envelope *Envelope, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	if envelope.IsExpired() {
+		return nil, fmt.Errorf("envelope expired at %v", envelope.ExpiresAt)
+	}
+	chainIdentifier, err := si.GetChainIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	if chainIdentifier != envelope.ChainIdentifier {
+		return nil, fmt.Errorf("envelope is for chain %v, node is on %v", envelope.ChainIdentifier, chainIdentifier)
+	}
+	// the envelope came back from another machine, its signers must still be those of the tx bytes
+	err = envelope.Verify()
+	if err != nil {
+		return nil, err
+	}
+	var signatures []string
+	for _, signer := range envelope.Signers {
+		signature, ok := envelope.Signatures[signer]
+		if !ok {
+			return nil, fmt.Errorf("missing signature of %v", signer)
+		}
+		err = checkSignatureAddress(signer, signature)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+	return si.SubmitSignedTransaction(envelope.TxBytes, signatures, options)
+}
@@ -0,0 +1,69 @@
+package go_sui_sdk
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/types"
+	"strconv"
+)
+
+// CurrentEpoch is the epoch of the latest checkpoint
+func (si *SuiClient) CurrentEpoch() (uint64, error) {
+	sequenceNumber, err := si.GetLatestCheckpointSequenceNumber()
+	if err != nil {
+		return 0, err
+	}
+	checkpoint, err := si.GetCheckpoint(sequenceNumber)
+	if err != nil {
+		return 0, err
+	}
+	epoch, err := strconv.ParseUint(checkpoint.Epoch, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch %v: %v", checkpoint.Epoch, err)
+	}
+	return epoch, nil
+}
+
+// ExpireAfterEpochs lets txData execute until the epoch that is epochs after the current one
+func (si *SuiClient) ExpireAfterEpochs(txData *types.TransactionData, epochs uint64) error {
+	epoch, err := si.CurrentEpoch()
+	if err != nil {
+		return err
+	}
+	txData.SetExpirationEpoch(epoch + epochs)
+	return nil
+}
+
+// ExpireTxBytesAfterEpochs is ExpireAfterEpochs for bytes built elsewhere, sign the returned bytes
+func (si *SuiClient) ExpireTxBytesAfterEpochs(txBytes string, epochs uint64) (string, error) {
+	txData, err := types.ParseTransactionData(txBytes)
+	if err != nil {
+		return "", err
+	}
+	err = si.ExpireAfterEpochs(txData, epochs)
+	if err != nil {
+		return "", err
+	}
+	return txData.TxBytes()
+}
+
+// CheckExpiration returns *types.TransactionExpiredError when the expiration epoch of txBytes has passed,
+// txBytes that are not a programmable transaction are not checked
+func (si *SuiClient) CheckExpiration(txBytes string) error {
+	txData, err := types.ParseTransactionData(txBytes)
+	var kindErr *types.UnsupportedTransactionKindError
+	if errors.As(err, &kindErr) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if txData.Expiration.Epoch == nil {
+		return nil
+	}
+	epoch, err := si.CurrentEpoch()
+	if err != nil {
+		return err
+	}
+	return txData.CheckExpiration(epoch)
+}
@@ -0,0 +1,254 @@
+package go_sui_sdk
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/crypto"
+	"github.com/ltp456/go-sui-sdk/types"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+const defaultGasMargin = 1.2
+
+// GasEstimator sets the gas budget of a transaction from a dry run of it
+type GasEstimator struct {
+	client *SuiClient
+	// Margin multiplies the dry run cost, 1.2 adds 20%
+	Margin float64
+	// MinBudget and MaxBudget clamp the estimate, types.MinGasFee and types.MaxGasFee by default
+	MinBudget uint64
+	MaxBudget uint64
+	// DryRunBudget replaces the budget of the transaction for the dry run, 0 keeps it.
+	// The gas coins must cover it.
+	DryRunBudget uint64
+}
+
+func NewGasEstimator(client *SuiClient) *GasEstimator {
+	return &GasEstimator{
+		client:    client,
+		Margin:    defaultGasMargin,
+		MinBudget: types.MinGasFee,
+		MaxBudget: types.MaxGasFee,
+	}
+}
+
+// EstimateBudget dry runs txBytes and returns max(computation, computation + storage - rebate)
+// multiplied by Margin, rounded up and clamped to MinBudget and MaxBudget
+func (ge *GasEstimator) EstimateBudget(txBytes string) (uint64, error) {
+	if ge.DryRunBudget > 0 {
+		var err error
+		txBytes, err = SetGasBudget(txBytes, ge.DryRunBudget)
+		if err != nil {
+			return 0, err
+		}
+	}
+	dryRun, err := ge.client.dryRunTransactionBlock(txBytes)
+	if err != nil {
+		return 0, err
+	}
+	if status := dryRun.Status(); status.Status != types.TxSuccess {
+		return 0, fmt.Errorf("dry run failed: %v", status.Error)
+	}
+	return gasBudget(dryRun.Effects.GasUsed, ge.Margin, ge.MinBudget, ge.MaxBudget)
+}
+
+// gasBudget is max(computation, computation + storage - rebate) times margin rounded up, clamped to minBudget and maxBudget,
+// a margin up to 0 counts as 1 and a maxBudget of 0 does not clamp
+func gasBudget(gasUsed types.GasUsed, margin float64, minBudget, maxBudget uint64) (uint64, error) {
+	var costs [3]*big.Int
+	for i, value := range []string{gasUsed.ComputationCost, gasUsed.StorageCost, gasUsed.StorageRebate} {
+		cost, ok := big.NewInt(0).SetString(value, 10)
+		if !ok {
+			return 0, fmt.Errorf("parse big error: %v", value)
+		}
+		costs[i] = cost
+	}
+	computation, storage, rebate := costs[0], costs[1], costs[2]
+	net := new(big.Int).Add(computation, storage)
+	net.Sub(net, rebate)
+	if net.Cmp(computation) < 0 {
+		net = computation
+	}
+	if margin <= 0 {
+		margin = 1
+	}
+	// Uint64 truncates, round up instead: 1.2 is a bit less than 1.2 in binary and would lose a MIST.
+	// A product past MaxUint64 saturates to it, maxBudget clamps it below.
+	product := new(big.Float).Mul(new(big.Float).SetInt(net), big.NewFloat(margin))
+	budget, _ := product.Uint64()
+	if !product.IsInt() && budget < math.MaxUint64 {
+		budget++
+	}
+	if budget < minBudget {
+		budget = minBudget
+	}
+	if maxBudget > 0 && budget > maxBudget {
+		budget = maxBudget
+	}
+	return budget, nil
+}
+
+// Estimate returns unsignedTx rebuilt with the estimated budget
+func (ge *GasEstimator) Estimate(unsignedTx *types.UnsignedTx) (*types.UnsignedTx, uint64, error) {
+	budget, err := ge.EstimateBudget(unsignedTx.TxBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	txBytes, err := SetGasBudget(unsignedTx.TxBytes, budget)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &types.UnsignedTx{TxBytes: txBytes, InputObjects: unsignedTx.InputObjects, Gas: unsignedTx.Gas}, budget, nil
+}
+
+// SignAndSubmitTx estimates the budget of unsignedTx before signing and submitting it
+func (ge *GasEstimator) SignAndSubmitTx(signer crypto.Signer, unsignedTx *types.UnsignedTx, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	estimated, _, err := ge.Estimate(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	return ge.client.SignAndSubmitTx(signer, estimated, options)
+}
+
+// SetGasBudget rebuilds txBytes with budget, signatures over the old bytes become invalid
+func SetGasBudget(txBytes string, budget uint64) (string, error) {
+	txData, err := types.ParseTransactionData(txBytes)
+	if err != nil {
+		return "", err
+	}
+	txData.GasData.Budget = strconv.FormatUint(budget, 10)
+	return txData.TxBytes()
+}
+
+// maxGasPaymentCoins is the protocol limit of gas payment objects
+const maxGasPaymentCoins = 256
+
+const gasPriceRetryInterval = time.Minute
+
+func (si *SuiClient) GetLatestSuiSystemState() (*types.SuiSystemStateSummary, error) {
+	result := &types.SuiSystemStateSummary{}
+	err := si.post("suix_getLatestSuiSystemState", nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReferenceGasPrice is the reference gas price of the current epoch, cached without any call until the
+// epoch of the system state it came from is due to end
+func (si *SuiClient) ReferenceGasPrice() (*big.Int, error) {
+	si.gasPriceLock.Lock()
+	defer si.gasPriceLock.Unlock()
+	if si.gasPrice != nil && time.Now().Before(si.gasPriceExpiry) {
+		return new(big.Int).Set(si.gasPrice), nil
+	}
+	state, err := si.GetLatestSuiSystemState()
+	if err != nil {
+		return nil, err
+	}
+	price, ok := big.NewInt(0).SetString(state.ReferenceGasPrice, 10)
+	if !ok {
+		return nil, fmt.Errorf("parse big error: %v", state.ReferenceGasPrice)
+	}
+	start, err := strconv.ParseInt(state.EpochStartTimestampMs, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch start %v: %v", state.EpochStartTimestampMs, err)
+	}
+	duration, err := strconv.ParseInt(state.EpochDurationMs, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch duration %v: %v", state.EpochDurationMs, err)
+	}
+	si.gasPrice = price
+	si.gasPriceExpiry = time.UnixMilli(start + duration)
+	// the epoch change is late, the same epoch is still running, ask again shortly
+	if minExpiry := time.Now().Add(gasPriceRetryInterval); si.gasPriceExpiry.Before(minExpiry) {
+		si.gasPriceExpiry = minExpiry
+	}
+	return new(big.Int).Set(price), nil
+}
+
+// GasPrice is the reference gas price times multiplier, a multiplier above 1 buys priority.
+// The node rejects a price below the reference price, so multiplier must be at least 1.
+func (si *SuiClient) GasPrice(multiplier float64) (*big.Int, error) {
+	if multiplier < 1 {
+		return nil, fmt.Errorf("gas price multiplier must be at least 1: %v", multiplier)
+	}
+	price, err := si.ReferenceGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	// round up like gasBudget, 1.2 times 750 must not end up below 900
+	product := new(big.Float).Mul(new(big.Float).SetInt(price), big.NewFloat(multiplier))
+	result, _ := product.Int(nil)
+	if !product.IsInt() {
+		result.Add(result, big.NewInt(1))
+	}
+	return result, nil
+}
+
+// SelectGasPayment picks SUI coins of owner covering budget, never one of exclude (the transaction inputs)
+func (si *SuiClient) SelectGasPayment(owner string, budget uint64, exclude []string) ([]types.Payment, error) {
+	coins, err := si.SelectCoins(owner, types.SuiCoinType, new(big.Int).SetUint64(budget), &CoinSelectOptions{
+		Strategy:  MinimizeInputs,
+		MaxInputs: maxGasPaymentCoins,
+		Exclude:   exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var payment []types.Payment
+	for _, coin := range coins {
+		ref, err := coin.ObjectRef()
+		if err != nil {
+			return nil, err
+		}
+		payment = append(payment, types.NewPayment(ref))
+	}
+	return payment, nil
+}
+
+type GasConfig struct {
+	// Owner pays the gas, the sender when empty
+	Owner  string
+	Budget uint64
+	// PriceMultiplier scales the reference gas price, 0 uses it as is and other values must be at least 1
+	PriceMultiplier float64
+	// Payment skips gas coin selection when set
+	Payment []types.Payment
+}
+
+// SetGasData fills the gas data of txData explicitly: owner, price, budget and payment coins
+// not used as transaction inputs. Run a GasEstimator over the result to tighten the budget.
+func (si *SuiClient) SetGasData(txData *types.TransactionData, config GasConfig) error {
+	if config.Budget == 0 {
+		return fmt.Errorf("gas budget is required")
+	}
+	owner := config.Owner
+	if owner == "" {
+		owner = txData.Sender.String()
+	}
+	multiplier := config.PriceMultiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	price, err := si.GasPrice(multiplier)
+	if err != nil {
+		return err
+	}
+	payment := config.Payment
+	if len(payment) == 0 {
+		payment, err = si.SelectGasPayment(owner, config.Budget, txData.Kind.InputObjectIDs())
+		if err != nil {
+			return err
+		}
+	}
+	txData.GasData = types.GasData{
+		Payment: payment,
+		Owner:   owner,
+		Price:   price.String(),
+		Budget:  strconv.FormatUint(config.Budget, 10),
+	}
+	return nil
+}
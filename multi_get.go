@@ -0,0 +1,129 @@
+package go_sui_sdk
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/types"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultConcurrency = 4
+	// MaxMultiGetLimit is the node limit of digests or ids per multi get call
+	MaxMultiGetLimit = 50
+)
+
+type TransactionBlockResult struct {
+	Digest string
+	Block  *types.TransactionBlock
+	Err    error
+}
+
+type ObjectResult struct {
+	ObjectID string
+	Object   *types.ObjData
+	Err      error
+}
+
+// MultiGetTransactionBlocks returns one result per digest in input order,
+// a digest the node does not know gets an error instead of failing the whole call.
+func (si *SuiClient) MultiGetTransactionBlocks(digests []string, options *types.TransactionBlockResponseOptions) []TransactionBlockResult {
+	results := make([]TransactionBlockResult, len(digests))
+	for i, digest := range digests {
+		results[i].Digest = digest
+	}
+	si.runChunks(len(digests), MaxMultiGetLimit, func(start, end int) {
+		chunk := results[start:end]
+		var blocks []types.TransactionBlock
+		params := Params{}
+		params.AddValue(digests[start:end])
+		params.AddValue(txOptions(options))
+		err := si.post("sui_multiGetTransactionBlocks", params, &blocks)
+		if err != nil {
+			for i := range chunk {
+				chunk[i].Err = err
+				// one unknown digest fails the call, fetch one by one to find it
+				if isTransactionNotFound(err) {
+					chunk[i].Block, chunk[i].Err = si.GetTransactionBlock(chunk[i].Digest, options)
+				}
+			}
+			return
+		}
+		blockMap := make(map[string]*types.TransactionBlock, len(blocks))
+		for i := range blocks {
+			blockMap[blocks[i].Digest] = &blocks[i]
+		}
+		for i := range chunk {
+			block, ok := blockMap[chunk[i].Digest]
+			if !ok {
+				chunk[i].Err = fmt.Errorf("transaction not found: %v", chunk[i].Digest)
+				continue
+			}
+			chunk[i].Block = block
+		}
+	})
+	return results
+}
+
+func isTransactionNotFound(err error) bool {
+	var rpcErr *Error
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "Could not find the referenced transaction")
+}
+
+// MultiGetObjects returns one result per id in input order, deleted or missing objects get an error
+func (si *SuiClient) MultiGetObjects(objectIds []string, options *types.ObjectDataOptions) []ObjectResult {
+	results := make([]ObjectResult, len(objectIds))
+	for i, objectId := range objectIds {
+		results[i].ObjectID = objectId
+	}
+	si.runChunks(len(objectIds), MaxMultiGetLimit, func(start, end int) {
+		chunk := results[start:end]
+		var objects []types.ObjData
+		params := Params{}
+		params.AddValue(objectIds[start:end])
+		params.AddValue(objectOptions(options))
+		err := si.post("sui_multiGetObjects", params, &objects)
+		if err == nil && len(objects) != len(chunk) {
+			err = fmt.Errorf("expect %v objects, got %v", len(chunk), len(objects))
+		}
+		for i := range chunk {
+			if err != nil {
+				chunk[i].Err = err
+				continue
+			}
+			if objects[i].Error != nil && objects[i].Error.Code != types.DisplayErrorCode {
+				chunk[i].Err = objects[i].Error.Err()
+				continue
+			}
+			chunk[i].Object = &objects[i]
+		}
+	})
+	return results
+}
+
+// runChunks calls fn for every [start, end) chunk of total, at most si.concurrency at a time
+func (si *SuiClient) runChunks(total, size int, fn func(start, end int)) {
+	concurrency := si.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for start := 0; start < total; start += size {
+		end := start + size
+		if end > total {
+			end = total
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package go_sui_sdk
+
+// Pager walks the items of a paged api one by one, fetch returns the next page and whether more pages follow.
+// fetch keeps its own cursor, e.g. the NextCursor of the page it got last.
+type Pager[T any] struct {
+	fetch   func() (items []T, hasNext bool, err error)
+	page    []T
+	index   int
+	started bool
+	hasNext bool
+	err     error
+}
+
+func NewPager[T any](fetch func() (items []T, hasNext bool, err error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch}
+}
+
+// Next moves to the next item, fetching pages as needed, it returns false at the end or on error
+func (p *Pager[T]) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	p.index++
+	for p.index >= len(p.page) {
+		if p.started && !p.hasNext {
+			return false
+		}
+		items, hasNext, err := p.fetch()
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.started = true
+		p.page, p.index, p.hasNext = items, 0, hasNext
+	}
+	return true
+}
+
+func (p *Pager[T]) Value() *T {
+	return &p.page[p.index]
+}
+
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// current is the item Next stopped at, nil before the first Next or after the last item
+func (p *Pager[T]) current() *T {
+	if p.index < len(p.page) {
+		return &p.page[p.index]
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package go_sui_sdk
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/ltp456/go-sui-sdk/types"
 	"net/url"
 	"sort"
 	"strings"
@@ -42,17 +43,51 @@ type JsonResp struct {
 	Id      int             `json:"id"`
 }
 
+// Error is the json rpc error returned by the node
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonRpc error: %v", e.Message)
+}
+
 type MapParams map[string]interface{}
 
 func (mp *MapParams) SetKey(key string, value interface{}) {
 	(*mp)[key] = value
 }
 
+func txOptions(options *types.TransactionBlockResponseOptions) *types.TransactionBlockResponseOptions {
+	if options == nil {
+		return types.DefaultTransactionBlockResponseOptions()
+	}
+	return options
+}
+
+func objectOptions(options *types.ObjectDataOptions) *types.ObjectDataOptions {
+	if options == nil {
+		return types.DefaultObjectDataOptions()
+	}
+	return options
+}
+
+// cursorParam and limitParam send null for the node default
+func cursorParam(cursor string) interface{} {
+	if cursor == "" {
+		return nil
+	}
+	return cursor
+}
+
+func limitParam(limit uint64) interface{} {
+	if limit == 0 {
+		return nil
+	}
+	return limit
+}
+
 type Params []interface{}
 
 func (p *Params) AddValue(value interface{}) {
@@ -0,0 +1,158 @@
+package go_sui_sdk
+
+import (
+	"github.com/ltp456/go-sui-sdk/types"
+)
+
+// QueryTransactionBlocks returns one page, cursor is empty for the first page and limit 0 uses the node default
+func (si *SuiClient) QueryTransactionBlocks(query types.TransactionBlockResponseQuery, cursor string, limit uint64, descending bool) (*types.TransactionBlocksPage, error) {
+	result := &types.TransactionBlocksPage{}
+	if query.Options == nil {
+		query.Options = types.DefaultTransactionBlockResponseOptions()
+	}
+	params := Params{}
+	params.AddValue(query)
+	params.AddValue(cursorParam(cursor))
+	params.AddValue(limitParam(limit))
+	params.AddValue(descending)
+	err := si.post("suix_queryTransactionBlocks", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TransactionBlockIterator walks all pages of a query:
+//
+//	it := client.NewTransactionBlockIterator(query, 50, false)
+//	for it.Next() {
+//		block := it.Value()
+//	}
+//	if it.Err() != nil {...}
+type TransactionBlockIterator struct {
+	pager  *Pager[types.TransactionBlock]
+	cursor string
+}
+
+func (si *SuiClient) NewTransactionBlockIterator(query types.TransactionBlockResponseQuery, limit uint64, descending bool) *TransactionBlockIterator {
+	it := &TransactionBlockIterator{}
+	it.pager = NewPager(func() ([]types.TransactionBlock, bool, error) {
+		page, err := si.QueryTransactionBlocks(query, it.cursor, limit, descending)
+		if err != nil {
+			return nil, false, err
+		}
+		it.cursor = page.NextCursor
+		return page.Data, page.HasNextPage, nil
+	})
+	return it
+}
+
+func (it *TransactionBlockIterator) Next() bool {
+	return it.pager.Next()
+}
+
+func (it *TransactionBlockIterator) Value() *types.TransactionBlock {
+	return it.pager.Value()
+}
+
+// Cursor is the cursor of the next page, items left in the current page are not covered by it
+func (it *TransactionBlockIterator) Cursor() string {
+	return it.cursor
+}
+
+func (it *TransactionBlockIterator) Err() error {
+	return it.pager.Err()
+}
+
+// QueryEvents returns one page, cursor is nil for the first page and limit 0 uses the node default
+func (si *SuiClient) QueryEvents(filter types.EventFilter, cursor *types.EventID, limit uint64, descending bool) (*types.EventsPage, error) {
+	result := &types.EventsPage{}
+	params := Params{}
+	params.AddValue(filter)
+	params.AddValue(cursor)
+	params.AddValue(limitParam(limit))
+	params.AddValue(descending)
+	err := si.post("suix_queryEvents", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type EventIterator struct {
+	pager  *Pager[types.TxEvent]
+	cursor *types.EventID
+}
+
+// NewEventIterator walks all pages of QueryEvents starting at cursor, nil starts from the first event
+func (si *SuiClient) NewEventIterator(filter types.EventFilter, cursor *types.EventID, limit uint64, descending bool) *EventIterator {
+	it := &EventIterator{cursor: cursor}
+	it.pager = NewPager(func() ([]types.TxEvent, bool, error) {
+		page, err := si.QueryEvents(filter, it.cursor, limit, descending)
+		if err != nil {
+			return nil, false, err
+		}
+		it.cursor = page.NextCursor
+		return page.Data, page.HasNextPage, nil
+	})
+	return it
+}
+
+func (it *EventIterator) Next() bool {
+	return it.pager.Next()
+}
+
+func (it *EventIterator) Value() *types.TxEvent {
+	return it.pager.Value()
+}
+
+// Cursor is the id of the current event, pass it to NewEventIterator to resume after it
+func (it *EventIterator) Cursor() *types.EventID {
+	if event := it.pager.current(); event != nil {
+		return &event.ID
+	}
+	return it.cursor
+}
+
+func (it *EventIterator) Err() error {
+	return it.pager.Err()
+}
+
+type OwnedObjectIterator struct {
+	pager  *Pager[types.ObjData]
+	cursor string
+}
+
+func (si *SuiClient) NewOwnedObjectIterator(address string, query *types.ObjectResponseQuery, limit uint64) *OwnedObjectIterator {
+	it := &OwnedObjectIterator{}
+	it.pager = NewPager(func() ([]types.ObjData, bool, error) {
+		page, err := si.GetOwnedObjects(it.cursor, address, query, limit)
+		if err != nil {
+			return nil, false, err
+		}
+		it.cursor = page.NextCursor
+		return page.Data, page.HasNextPage, nil
+	})
+	return it
+}
+
+// NewOwnedObjectsOfTypeIterator streams the objects of structType owned by address, nil options shows type and owner
+func (si *SuiClient) NewOwnedObjectsOfTypeIterator(address, structType string, options *types.ObjectDataOptions) *OwnedObjectIterator {
+	query := &types.ObjectResponseQuery{
+		Filter:  types.StructTypeObjectFilter(structType),
+		Options: objectOptions(options),
+	}
+	return si.NewOwnedObjectIterator(address, query, 0)
+}
+
+func (it *OwnedObjectIterator) Next() bool {
+	return it.pager.Next()
+}
+
+func (it *OwnedObjectIterator) Value() *types.Object {
+	return &it.pager.Value().Data
+}
+
+func (it *OwnedObjectIterator) Err() error {
+	return it.pager.Err()
+}
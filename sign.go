@@ -0,0 +1,85 @@
+package go_sui_sdk
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/crypto"
+	"github.com/ltp456/go-sui-sdk/types"
+)
+
+func SignIntentMessage(signer crypto.Signer, message *types.IntentMessage) (string, error) {
+	digest := message.Digest()
+	signatureData, err := crypto.SignDigest(signer, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signatureData), nil
+}
+
+func SignTransaction(signer crypto.Signer, txBytes string) (string, error) {
+	txData, err := base64.StdEncoding.DecodeString(txBytes)
+	if err != nil {
+		return "", err
+	}
+	return SignIntentMessage(signer, types.NewTransactionIntentMessage(txData))
+}
+
+func SignPersonalMessage(signer crypto.Signer, message []byte) (string, error) {
+	return SignIntentMessage(signer, types.NewPersonalIntentMessage(message))
+}
+
+// SignTransactionWithZkLogin signs with the ephemeral key and wraps the result into a zkLogin signature
+func SignTransactionWithZkLogin(ephemeral crypto.Signer, inputs crypto.ZkLoginInputs, maxEpoch uint64, txBytes string) (string, error) {
+	userSignature, err := SignTransaction(ephemeral, txBytes)
+	if err != nil {
+		return "", err
+	}
+	userSignatureData, err := base64.StdEncoding.DecodeString(userSignature)
+	if err != nil {
+		return "", err
+	}
+	signatureData, err := crypto.NewZkLoginSignature(inputs, maxEpoch, userSignatureData).Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signatureData), nil
+}
+
+// CheckSignatures checks there is one signature per signer of txBytes and that each comes from a signer,
+// by the address of its public key, multisig key set or zkLogin identity. The node verifies the signatures.
+func CheckSignatures(txBytes string, signatures []string) error {
+	txData, err := types.ParseTransactionData(txBytes)
+	if err != nil {
+		return err
+	}
+	signers := txData.Signers()
+	if len(signatures) != len(signers) {
+		return fmt.Errorf("expect %v signatures for %v, got %v", len(signers), signers, len(signatures))
+	}
+	signed := map[string]bool{}
+	for _, signature := range signatures {
+		signatureData, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature %v: %v", signature, err)
+		}
+		address, err := crypto.SignatureAddress(signatureData)
+		if err != nil {
+			return err
+		}
+		address = types.NormalizeAddress(address)
+		if signed[address] {
+			return fmt.Errorf("duplicate signature of %v", address)
+		}
+		signed[address] = true
+	}
+	for address := range signed {
+		found := false
+		for _, signer := range signers {
+			found = found || signer == address
+		}
+		if !found {
+			return fmt.Errorf("signature of %v is not required, signers are %v", address, signers)
+		}
+	}
+	return nil
+}
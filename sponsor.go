@@ -0,0 +1,94 @@
+package go_sui_sdk
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/crypto"
+	"github.com/ltp456/go-sui-sdk/types"
+)
+
+// SponsoredTransaction is a transaction whose gas is paid by Sponsor, both Sender and Sponsor sign TxBytes.
+// It is plain json so it can travel between the user and the sponsor.
+type SponsoredTransaction struct {
+	TxBytes          string `json:"txBytes"`
+	Sender           string `json:"sender"`
+	Sponsor          string `json:"sponsor"`
+	SenderSignature  string `json:"senderSignature,omitempty"`
+	SponsorSignature string `json:"sponsorSignature,omitempty"`
+}
+
+// SponsorTransaction makes sponsor the gas owner of txData and pays with the sponsor's coins,
+// config.Payment can name them explicitly
+func (si *SuiClient) SponsorTransaction(txData *types.TransactionData, sponsor string, config GasConfig) (*SponsoredTransaction, error) {
+	config.Owner = sponsor
+	err := si.SetGasData(txData, config)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &SponsoredTransaction{
+		TxBytes: txBytes,
+		Sender:  txData.Sender.String(),
+		Sponsor: sponsor,
+	}, nil
+}
+
+// SponsorTxBytes is SponsorTransaction for bytes built elsewhere, e.g. by the unsafe_* builders
+func (si *SuiClient) SponsorTxBytes(txBytes, sponsor string, config GasConfig) (*SponsoredTransaction, error) {
+	txData, err := types.ParseTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return si.SponsorTransaction(txData, sponsor, config)
+}
+
+func (st *SponsoredTransaction) SignAsSender(signer crypto.Signer) error {
+	signature, err := st.sign(signer, st.Sender)
+	if err != nil {
+		return err
+	}
+	st.SenderSignature = signature
+	return nil
+}
+
+func (st *SponsoredTransaction) SignAsSponsor(signer crypto.Signer) error {
+	signature, err := st.sign(signer, st.Sponsor)
+	if err != nil {
+		return err
+	}
+	st.SponsorSignature = signature
+	return nil
+}
+
+func (st *SponsoredTransaction) sign(signer crypto.Signer, expect string) (string, error) {
+	address, err := crypto.SignerAddress(signer)
+	if err != nil {
+		return "", err
+	}
+	if types.NormalizeAddress(address) != types.NormalizeAddress(expect) {
+		return "", fmt.Errorf("signer %v is not %v", address, expect)
+	}
+	return SignTransaction(signer, st.TxBytes)
+}
+
+// Signatures returns the sender and sponsor signatures, in the order the node expects
+func (st *SponsoredTransaction) Signatures() ([]string, error) {
+	if st.SenderSignature == "" {
+		return nil, fmt.Errorf("missing sender signature of %v", st.Sender)
+	}
+	if st.SponsorSignature == "" {
+		return nil, fmt.Errorf("missing sponsor signature of %v", st.Sponsor)
+	}
+	return []string{st.SenderSignature, st.SponsorSignature}, nil
+}
+
+// ExecuteSponsoredTransaction submits a transaction signed by both the sender and the sponsor
+func (si *SuiClient) ExecuteSponsoredTransaction(st *SponsoredTransaction, options *types.TransactionBlockResponseOptions) (*types.TransactionBlock, error) {
+	signatures, err := st.Signatures()
+	if err != nil {
+		return nil, err
+	}
+	return si.SubmitSignedTransaction(st.TxBytes, signatures, options)
+}
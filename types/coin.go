@@ -0,0 +1,163 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const SuiDecimals = 9
+
+// MistPerSui is 10^SuiDecimals, MIST is the base unit of SUI
+const MistPerSui = 1000000000
+
+type CoinMetadata struct {
+	Decimals    uint8   `json:"decimals"`
+	Name        string  `json:"name"`
+	Symbol      string  `json:"symbol"`
+	Description string  `json:"description"`
+	IconUrl     *string `json:"iconUrl"`
+	ID          *string `json:"id"`
+}
+
+// Amount formats the base unit value with the coin decimals and symbol
+func (cm *CoinMetadata) Amount(value *big.Int) Amount {
+	return NewAmount(value, cm.Decimals, cm.Symbol)
+}
+
+// ParseAmount parses "1.5" or "1.5 USDC", the symbol must match the coin symbol when given
+func (cm *CoinMetadata) ParseAmount(amount string) (Amount, error) {
+	fields := strings.Fields(amount)
+	if len(fields) == 2 {
+		if fields[1] != cm.Symbol {
+			return Amount{}, fmt.Errorf("amount symbol %v not match %v", fields[1], cm.Symbol)
+		}
+	} else if len(fields) != 1 {
+		return Amount{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+	value, err := ParseDecimal(fields[0], cm.Decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	return cm.Amount(value), nil
+}
+
+type Supply struct {
+	Value string `json:"value"`
+}
+
+// Amount is a coin value in base units together with the decimals used to display it
+type Amount struct {
+	Value    *big.Int
+	Decimals uint8
+	Symbol   string
+}
+
+// NewAmount copies value, nil is 0
+func NewAmount(value *big.Int, decimals uint8, symbol string) Amount {
+	if value == nil {
+		return Amount{Value: big.NewInt(0), Decimals: decimals, Symbol: symbol}
+	}
+	return Amount{Value: new(big.Int).Set(value), Decimals: decimals, Symbol: symbol}
+}
+
+func NewSuiAmount(mist *big.Int) Amount {
+	return NewAmount(mist, SuiDecimals, "SUI")
+}
+
+// Decimal returns the value without symbol, e.g. 1500000 with 6 decimals is "1.5"
+func (a Amount) Decimal() string {
+	return FormatDecimal(a.Value, a.Decimals)
+}
+
+// String returns the decimal value followed by the symbol, e.g. "1.5 USDC"
+func (a Amount) String() string {
+	if a.Symbol == "" {
+		return a.Decimal()
+	}
+	return a.Decimal() + " " + a.Symbol
+}
+
+// FormatDecimal renders a base unit value with decimals, trailing fraction zeros are dropped
+func FormatDecimal(value *big.Int, decimals uint8) string {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	digits := new(big.Int).Abs(value).String()
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	if decimals == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// ParseDecimal parses a human amount like "1.5" into base units, more fraction digits than decimals is an error
+func ParseDecimal(amount string, decimals uint8) (*big.Int, error) {
+	text := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	integer, fraction := text, ""
+	if index := strings.Index(text, "."); index >= 0 {
+		integer, fraction = text[:index], text[index+1:]
+	}
+	if integer == "" && fraction == "" || len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("invalid amount %q for %v decimals", amount, decimals)
+	}
+	if integer == "" {
+		integer = "0"
+	}
+	for _, c := range integer + fraction {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid amount: %q", amount)
+		}
+	}
+	value, ok := new(big.Int).SetString(integer+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+	if negative {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+// SuiToMist parses a SUI amount like "0.01" into MIST
+func SuiToMist(sui string) (*big.Int, error) {
+	return ParseDecimal(sui, SuiDecimals)
+}
+
+// MistToSui formats MIST as SUI without symbol
+func MistToSui(mist *big.Int) string {
+	return FormatDecimal(mist, SuiDecimals)
+}
+
+// InsufficientBalanceError reports a coin selection shortfall, Available is the most the usable coins
+// can cover within MaxInputs (0 for no limit)
+type InsufficientBalanceError struct {
+	CoinType  string
+	Required  *big.Int
+	Available *big.Int
+	MaxInputs int
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	if e.MaxInputs > 0 {
+		return fmt.Sprintf("insufficient balance of %v: required %v, available %v within %v inputs", e.CoinType, e.Required, e.Available, e.MaxInputs)
+	}
+	return fmt.Sprintf("insufficient balance of %v: required %v, available %v", e.CoinType, e.Required, e.Available)
+}
+
+// Shortfall is Required minus Available
+func (e *InsufficientBalanceError) Shortfall() *big.Int {
+	return new(big.Int).Sub(e.Required, e.Available)
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAmount(t *testing.T) {
+	metadata := &CoinMetadata{Decimals: 6, Symbol: "USDC"}
+	amount, err := metadata.ParseAmount("1.5 USDC")
+	if err != nil {
+		panic(err)
+	}
+	if amount.Value.String() != "1500000" || amount.String() != "1.5 USDC" {
+		t.Fatalf("unexpected amount: %v %v", amount.Value, amount)
+	}
+	if _, err := metadata.ParseAmount("1.0000001"); err == nil {
+		t.Fatalf("too many decimals accepted")
+	}
+	mist, err := SuiToMist("0.01")
+	if err != nil {
+		panic(err)
+	}
+	if mist.String() != "10000000" || MistToSui(mist) != "0.01" {
+		t.Fatalf("unexpected mist: %v", mist)
+	}
+
+	value := big.NewInt(2500000000)
+	amount = NewSuiAmount(value)
+	value.SetInt64(1)
+	if amount.String() != "2.5 SUI" {
+		t.Fatalf("amount shares the caller's value: %v", amount)
+	}
+	for _, amount := range []Amount{NewAmount(nil, 6, "USDC"), NewSuiAmount(nil), metadata.Amount(nil)} {
+		if amount.Value == nil || amount.Value.Sign() != 0 || amount.Decimal() != "0" {
+			t.Fatalf("unexpected nil amount: %+v", amount)
+		}
+	}
+}
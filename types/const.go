@@ -4,8 +4,8 @@ const MinGasFee = 2000
 
 const MaxGasFee = 50000000000
 
-// 这在sui代码中定义
-var IntentFlag = []byte{0, 0, 0}
+// IntentFlag is the transaction data intent prefix defined by sui, prefer TransactionDataIntent
+var IntentFlag = TransactionDataIntent().Bytes()
 
 type SignatureSchemeSerialized byte
 
@@ -109,3 +109,18 @@ const (
 	TxSuccess string = "success"
 	TxFailure string = "failure"
 )
+
+type ObjectChangeType string
+
+const (
+	PublishedObjectChange   ObjectChangeType = "published"
+	TransferredObjectChange ObjectChangeType = "transferred"
+	MutatedObjectChange     ObjectChangeType = "mutated"
+	DeletedObjectChange     ObjectChangeType = "deleted"
+	WrappedObjectChange     ObjectChangeType = "wrapped"
+	CreatedObjectChange     ObjectChangeType = "created"
+)
+
+func (oct ObjectChangeType) String() string {
+	return string(oct)
+}
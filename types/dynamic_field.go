@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	DynamicFieldType       = "DynamicField"
+	DynamicObjectFieldType = "DynamicObject"
+)
+
+// DynamicFieldName is the key of a dynamic field: {"type": "u64", "value": "1"}, {"type": "address", "value": "0x..."}
+type DynamicFieldName struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type DynamicFieldInfo struct {
+	Name       DynamicFieldName `json:"name"`
+	BcsName    string           `json:"bcsName"`
+	Type       string           `json:"type"`
+	ObjectType string           `json:"objectType"`
+	ObjectID   string           `json:"objectId"`
+	Version    json.Number      `json:"version"`
+	Digest     string           `json:"digest"`
+}
+
+type DynamicFieldPage struct {
+	Data        []DynamicFieldInfo `json:"data"`
+	NextCursor  string             `json:"nextCursor"`
+	HasNextPage bool               `json:"hasNextPage"`
+}
+
+// Table is 0x2::table::Table<K, V>, its entries are dynamic fields of ID
+type Table struct {
+	ID   UID     `json:"id"`
+	Size MoveU64 `json:"size"`
+}
+
+// Bag is 0x2::bag::Bag, its entries are dynamic fields of ID
+type Bag struct {
+	ID   UID     `json:"id"`
+	Size MoveU64 `json:"size"`
+}
+
+// DecodeDynamicFieldValue decodes the value of a dynamic field object:
+// the value field of 0x2::dynamic_field::Field<K, V>, or the object itself for dynamic object fields.
+func (o *Object) DecodeDynamicFieldValue(value interface{}) error {
+	if o.Content == nil || !o.Content.IsMoveObject() {
+		return fmt.Errorf("object %v has no move content, read it with showContent", o.ObjectID)
+	}
+	if !strings.HasPrefix(NormalizeType(o.Content.Type), NormalizeType("0x2::dynamic_field::Field<")) {
+		return o.DecodeFields(value)
+	}
+	var field struct {
+		Value json.RawMessage `json:"value"`
+	}
+	err := json.Unmarshal(o.Content.Fields, &field)
+	if err != nil {
+		return err
+	}
+	return DecodeMoveFields(field.Value, value)
+}
@@ -1,31 +1,134 @@
 package types
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"time"
+)
+
 type TxID struct {
 	TxDigest string `json:"txDigest"`
 	EventSeq string `json:"eventSeq"`
 }
 
-type DolaUserAddress struct {
-	DolaAddress []int `json:"dola_address"`
-	DolaChainID int   `json:"dola_chain_id"`
+type EventID = TxID
+
+type TxEvent struct {
+	ID                TxID            `json:"id"`
+	PackageID         string          `json:"packageId"`
+	TransactionModule string          `json:"transactionModule"`
+	Sender            string          `json:"sender"`
+	Type              string          `json:"type"`
+	ParsedJSON        json.RawMessage `json:"parsedJson,omitempty"`
+	BcsEncoding       string          `json:"bcsEncoding,omitempty"`
+	Bcs               string          `json:"bcs"`
+	TimestampMs       string          `json:"timestampMs,omitempty"`
 }
 
-type ParsedJSON struct {
-	CallType        int             `json:"call_type"`
-	Nonce           string          `json:"nonce"`
-	Sender          string          `json:"sender"`
-	UserAddress     []int           `json:"user_address"`
-	UserChainID     int             `json:"user_chain_id"`
-	DolaUserAddress DolaUserAddress `json:"dola_user_address"`
-	DolaUserID      string          `json:"dola_user_id"`
+// DecodeParsedJSON decodes parsedJson into value, note move u64 and larger integers are json strings
+func (te *TxEvent) DecodeParsedJSON(value interface{}) error {
+	if len(te.ParsedJSON) == 0 {
+		return fmt.Errorf("event %v has no parsedJson", te.Type)
+	}
+	return json.Unmarshal(te.ParsedJSON, value)
 }
 
-type TxEvent struct {
-	ID                TxID       `json:"id"`
-	PackageID         string     `json:"packageId"`
-	TransactionModule string     `json:"transactionModule"`
-	Sender            string     `json:"sender"`
-	Type              string     `json:"type"`
-	ParsedJSON        ParsedJSON `json:"parsedJson,omitempty"`
-	Bcs               string     `json:"bcs"`
+// BcsBytes returns the bcs bytes of the event, base58 unless bcsEncoding says base64
+func (te *TxEvent) BcsBytes() ([]byte, error) {
+	if te.BcsEncoding == "base64" {
+		return base64.StdEncoding.DecodeString(te.Bcs)
+	}
+	return Base58Decode(te.Bcs)
+}
+
+func (te *TxEvent) DecodeBcs(value interface{}) error {
+	data, err := te.BcsBytes()
+	if err != nil {
+		return err
+	}
+	return bcs.Unmarshal(data, value)
+}
+
+type MoveModuleFilter struct {
+	Package string `json:"package"`
+	Module  string `json:"module"`
+}
+
+type TimeRange struct {
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+// EventFilter holds exactly one filter, build it with the *EventFilter constructors
+type EventFilter struct {
+	Sender          string            `json:"Sender,omitempty"`
+	Transaction     string            `json:"Transaction,omitempty"`
+	MoveModule      *MoveModuleFilter `json:"MoveModule,omitempty"`
+	MoveEventType   string            `json:"MoveEventType,omitempty"`
+	MoveEventModule *MoveModuleFilter `json:"MoveEventModule,omitempty"`
+	TimeRange       *TimeRange        `json:"TimeRange,omitempty"`
+	All             *[]EventFilter    `json:"All,omitempty"`
+	Any             *[]EventFilter    `json:"Any,omitempty"`
+	And             []EventFilter     `json:"And,omitempty"`
+	Or              []EventFilter     `json:"Or,omitempty"`
+}
+
+func SenderEventFilter(sender string) EventFilter {
+	return EventFilter{Sender: sender}
+}
+
+func TransactionEventFilter(digest string) EventFilter {
+	return EventFilter{Transaction: digest}
+}
+
+// MoveModuleEventFilter matches events emitted by transactions calling the module
+func MoveModuleEventFilter(packageId, module string) EventFilter {
+	return EventFilter{MoveModule: &MoveModuleFilter{Package: packageId, Module: module}}
+}
+
+func MoveEventTypeFilter(eventType string) EventFilter {
+	return EventFilter{MoveEventType: eventType}
+}
+
+// MoveEventModuleFilter matches events whose type is defined in the module
+func MoveEventModuleFilter(packageId, module string) EventFilter {
+	return EventFilter{MoveEventModule: &MoveModuleFilter{Package: packageId, Module: module}}
+}
+
+func TimeRangeEventFilter(start, end time.Time) EventFilter {
+	return EventFilter{TimeRange: &TimeRange{
+		StartTime: fmt.Sprintf("%v", start.UnixMilli()),
+		EndTime:   fmt.Sprintf("%v", end.UnixMilli()),
+	}}
+}
+
+// AllEventFilter matches events matching every filter, no filter matches all events
+func AllEventFilter(filters ...EventFilter) EventFilter {
+	if filters == nil {
+		filters = []EventFilter{}
+	}
+	return EventFilter{All: &filters}
+}
+
+func AnyEventFilter(filters ...EventFilter) EventFilter {
+	if filters == nil {
+		filters = []EventFilter{}
+	}
+	return EventFilter{Any: &filters}
+}
+
+func AndEventFilter(left, right EventFilter) EventFilter {
+	return EventFilter{And: []EventFilter{left, right}}
+}
+
+func OrEventFilter(left, right EventFilter) EventFilter {
+	return EventFilter{Or: []EventFilter{left, right}}
+}
+
+type EventsPage struct {
+	Data        []TxEvent `json:"data"`
+	NextCursor  *EventID  `json:"nextCursor"`
+	HasNextPage bool      `json:"hasNextPage"`
 }
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type EventSource int
+
+const (
+	// DecodeFromParsedJSON uses parsedJson, falling back to bcs when the node sent none
+	DecodeFromParsedJSON EventSource = iota
+	DecodeFromBcs
+)
+
+type eventSchema struct {
+	goType reflect.Type
+	source EventSource
+}
+
+// EventRegistry maps move event types to go structs:
+//
+//	registry.Register("0xabc::pool::SwapEvent", SwapEvent{}, types.DecodeFromParsedJSON)
+//	value, err := registry.Decode(&event) // value is *SwapEvent
+//
+// A type registered without type parameters matches every instantiation of it.
+type EventRegistry struct {
+	lock    sync.RWMutex
+	schemas map[string]eventSchema
+}
+
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{schemas: map[string]eventSchema{}}
+}
+
+// Register binds eventType to the type of prototype, a struct value or a pointer to one
+func (er *EventRegistry) Register(eventType string, prototype interface{}, source EventSource) error {
+	goType := reflect.TypeOf(prototype)
+	if goType == nil {
+		return fmt.Errorf("nil prototype for event %v", eventType)
+	}
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+	er.lock.Lock()
+	defer er.lock.Unlock()
+	er.schemas[NormalizeType(eventType)] = eventSchema{goType: goType, source: source}
+	return nil
+}
+
+func (er *EventRegistry) Unregister(eventType string) {
+	er.lock.Lock()
+	defer er.lock.Unlock()
+	delete(er.schemas, NormalizeType(eventType))
+}
+
+func (er *EventRegistry) lookup(eventType string) (eventSchema, bool) {
+	er.lock.RLock()
+	defer er.lock.RUnlock()
+	eventType = NormalizeType(eventType)
+	schema, ok := er.schemas[eventType]
+	if !ok {
+		if index := strings.Index(eventType, "<"); index >= 0 {
+			schema, ok = er.schemas[eventType[:index]]
+		}
+	}
+	return schema, ok
+}
+
+// IsRegistered reports whether Decode knows the type of event
+func (er *EventRegistry) IsRegistered(event *TxEvent) bool {
+	_, ok := er.lookup(event.Type)
+	return ok
+}
+
+// Decode returns a pointer to a new value of the registered go type
+func (er *EventRegistry) Decode(event *TxEvent) (interface{}, error) {
+	schema, ok := er.lookup(event.Type)
+	if !ok {
+		return nil, fmt.Errorf("event type not registered: %v", event.Type)
+	}
+	value := reflect.New(schema.goType).Interface()
+	var err error
+	if schema.source == DecodeFromBcs || len(event.ParsedJSON) == 0 {
+		err = event.DecodeBcs(value)
+	} else {
+		err = event.DecodeParsedJSON(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode event %v error: %v", event.Type, err)
+	}
+	return value, nil
+}
+
+// DecodeAll decodes the registered events and skips the others
+func (er *EventRegistry) DecodeAll(events []TxEvent) ([]interface{}, error) {
+	var result []interface{}
+	for i := range events {
+		if !er.IsRegistered(&events[i]) {
+			continue
+		}
+		value, err := er.Decode(&events[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
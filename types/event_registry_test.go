@@ -0,0 +1,119 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testSwapEvent struct {
+	Pool      Address `json:"pool"`
+	AmountIn  MoveU64 `json:"amount_in"`
+	AmountOut MoveU64 `json:"amount_out"`
+	A2B       bool    `json:"a2b"`
+	FeeAmount MoveU64 `json:"fee_amount"`
+}
+
+type testCurrencyCreated struct {
+	Decimals uint8 `json:"decimals"`
+}
+
+func TestEventRegistry(t *testing.T) {
+	var events []TxEvent
+	loadFixture(t, "events.json", &events)
+	expect := testSwapEvent{
+		Pool:      "0xcf994611fd4c48e277ce3ffd4d4364c914af2c3cbb05f7bf6facd371de688630",
+		AmountIn:  1000000000,
+		AmountOut: 1834512,
+		A2B:       true,
+		FeeAmount: 2500000,
+	}
+
+	registry := NewEventRegistry()
+	// registered without type parameters, matches every pool
+	err := registry.Register("0x1eabed72c53feb3805120a081dc15963c204dc8d091542592abaf7a35689b2fb::pool::SwapEvent", &testSwapEvent{}, DecodeFromParsedJSON)
+	if err != nil {
+		panic(err)
+	}
+	if err := registry.Register("0x2::coin::CurrencyCreated", nil, DecodeFromBcs); err == nil {
+		t.Fatalf("expect error for nil prototype")
+	}
+	if registry.IsRegistered(&events[2]) {
+		t.Fatalf("%v is not registered", events[2].Type)
+	}
+	if _, err := registry.Decode(&events[2]); err == nil {
+		t.Fatalf("expect error for unregistered event")
+	}
+
+	// parsedJson, then bcs for the event without parsedJson
+	for _, event := range events[:2] {
+		value, err := registry.Decode(&event)
+		if err != nil {
+			t.Fatalf("event %v: %v", event.ID.EventSeq, err)
+		}
+		swap, ok := value.(*testSwapEvent)
+		if !ok || *swap != expect {
+			t.Fatalf("event %v: %+v, expect %+v", event.ID.EventSeq, value, expect)
+		}
+	}
+	values, err := registry.DecodeAll(events)
+	if err != nil {
+		panic(err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("unexpected decoded events: %v", len(values))
+	}
+
+	// the short address form of the type matches the long one
+	err = registry.Register("0x2::coin::CurrencyCreated", testCurrencyCreated{}, DecodeFromBcs)
+	if err != nil {
+		panic(err)
+	}
+	value, err := registry.Decode(&events[2])
+	if err != nil {
+		panic(err)
+	}
+	if created := value.(*testCurrencyCreated); created.Decimals != 9 {
+		t.Fatalf("unexpected decimals: %v", created.Decimals)
+	}
+	registry.Unregister("0x0000000000000000000000000000000000000000000000000000000000000002::coin::CurrencyCreated")
+	if registry.IsRegistered(&events[2]) {
+		t.Fatalf("%v is still registered", events[2].Type)
+	}
+
+	var swap testSwapEvent
+	if err := events[0].DecodeBcs(&swap); err != nil || swap != expect {
+		t.Fatalf("bcs %+v %v, expect %+v", swap, err, expect)
+	}
+	if err := events[1].DecodeParsedJSON(&swap); err == nil {
+		t.Fatalf("expect error for event without parsedJson")
+	}
+}
+
+func TestBase58(t *testing.T) {
+	cases := []struct {
+		data    []byte
+		encoded string
+	}{
+		{[]byte("hello world"), "StV1DL6CwTryKyV"},
+		{[]byte{0, 0, 1}, "112"},
+		{[]byte{9}, "A"},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		if encoded := Base58Encode(c.data); encoded != c.encoded {
+			t.Fatalf("encode %x: %v, expect %v", c.data, encoded, c.encoded)
+		}
+		data, err := Base58Decode(c.encoded)
+		if err != nil {
+			t.Fatalf("decode %v: %v", c.encoded, err)
+		}
+		if !bytes.Equal(data, c.data) {
+			t.Fatalf("decode %v: %x, expect %x", c.encoded, data, c.data)
+		}
+	}
+	for _, invalid := range []string{"0", "O", "I", "l", "abc+"} {
+		if _, err := Base58Decode(invalid); err == nil {
+			t.Fatalf("expect error for %v", invalid)
+		}
+	}
+}
@@ -1 +1,78 @@
 package types
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var typeAddressRegexp = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+// NormalizeAddress pads an address or object id to 32 bytes: 0x2 -> 0x000...02
+func NormalizeAddress(address string) string {
+	address = strings.ToLower(strings.TrimPrefix(address, "0x"))
+	if len(address) < 64 {
+		address = strings.Repeat("0", 64-len(address)) + address
+	}
+	return "0x" + address
+}
+
+// NormalizeType normalizes every address of a move type: 0x2::coin::Coin<0x2::sui::SUI>
+func NormalizeType(moveType string) string {
+	return typeAddressRegexp.ReplaceAllStringFunc(strings.TrimSpace(moveType), NormalizeAddress)
+}
+
+// MatchType reports whether moveType is pattern, a pattern without type parameters matches any instantiation
+func MatchType(moveType, pattern string) bool {
+	moveType = NormalizeType(moveType)
+	pattern = NormalizeType(pattern)
+	if moveType == pattern {
+		return true
+	}
+	if !strings.Contains(pattern, "<") {
+		if index := strings.Index(moveType, "<"); index >= 0 {
+			return moveType[:index] == pattern
+		}
+	}
+	return false
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58Decode decodes digests and event bcs, which the node returns base58 encoded
+func Base58Decode(value string) ([]byte, error) {
+	result := big.NewInt(0)
+	radix := big.NewInt(58)
+	for _, c := range value {
+		index := strings.IndexRune(base58Alphabet, c)
+		if index < 0 {
+			return nil, fmt.Errorf("invalid base58 character: %q", c)
+		}
+		result.Mul(result, radix)
+		result.Add(result, big.NewInt(int64(index)))
+	}
+	zeros := 0
+	for zeros < len(value) && value[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), result.Bytes()...), nil
+}
+
+func Base58Encode(data []byte) string {
+	value := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var result []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		result = append(result, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < len(data) && data[i] == 0; i++ {
+		result = append(result, base58Alphabet[0])
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return string(result)
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"golang.org/x/crypto/blake2b"
+)
+
+type IntentScope byte
+
+const (
+	IntentScopeTransactionData         IntentScope = 0
+	IntentScopeTransactionEffects      IntentScope = 1
+	IntentScopeCheckpointSummary       IntentScope = 2
+	IntentScopePersonalMessage         IntentScope = 3
+	IntentScopeSenderSignedTransaction IntentScope = 4
+	IntentScopeProofOfPossession       IntentScope = 5
+	IntentScopeHeaderDigest            IntentScope = 6
+	IntentScopeBridgeEventUnused       IntentScope = 7
+	IntentScopeConsensusBlock          IntentScope = 8
+	IntentScopeDiscoveryPeers          IntentScope = 9
+)
+
+type IntentVersion byte
+
+const (
+	IntentVersionV0 IntentVersion = 0
+)
+
+type AppId byte
+
+const (
+	AppIdSui       AppId = 0
+	AppIdNarwhal   AppId = 1
+	AppIdConsensus AppId = 2
+)
+
+type Intent struct {
+	Scope   IntentScope
+	Version IntentVersion
+	AppId   AppId
+}
+
+func NewIntent(scope IntentScope, version IntentVersion, appId AppId) Intent {
+	return Intent{Scope: scope, Version: version, AppId: appId}
+}
+
+func TransactionDataIntent() Intent {
+	return NewIntent(IntentScopeTransactionData, IntentVersionV0, AppIdSui)
+}
+
+func TransactionEffectsIntent() Intent {
+	return NewIntent(IntentScopeTransactionEffects, IntentVersionV0, AppIdSui)
+}
+
+func CheckpointSummaryIntent() Intent {
+	return NewIntent(IntentScopeCheckpointSummary, IntentVersionV0, AppIdSui)
+}
+
+func PersonalMessageIntent() Intent {
+	return NewIntent(IntentScopePersonalMessage, IntentVersionV0, AppIdSui)
+}
+
+func SenderSignedTransactionIntent() Intent {
+	return NewIntent(IntentScopeSenderSignedTransaction, IntentVersionV0, AppIdSui)
+}
+
+func ProofOfPossessionIntent() Intent {
+	return NewIntent(IntentScopeProofOfPossession, IntentVersionV0, AppIdSui)
+}
+
+func NarwhalHeaderDigestIntent() Intent {
+	return NewIntent(IntentScopeHeaderDigest, IntentVersionV0, AppIdNarwhal)
+}
+
+func ConsensusBlockIntent() Intent {
+	return NewIntent(IntentScopeConsensusBlock, IntentVersionV0, AppIdConsensus)
+}
+
+func (i Intent) Bytes() []byte {
+	return []byte{byte(i.Scope), byte(i.Version), byte(i.AppId)}
+}
+
+func (i Intent) MarshalBCS(e *bcs.Encoder) error {
+	e.WriteFixedBytes(i.Bytes())
+	return nil
+}
+
+// IntentMessage is the bcs serialized value prefixed with its intent, signatures are made over Digest
+type IntentMessage struct {
+	Intent Intent
+	Value  []byte
+}
+
+// NewIntentMessage serializes value with bcs
+func NewIntentMessage(intent Intent, value interface{}) (*IntentMessage, error) {
+	data, err := bcs.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewIntentMessageFromBcs(intent, data), nil
+}
+
+// NewIntentMessageFromBcs uses an already bcs serialized value, e.g. transaction bytes
+func NewIntentMessageFromBcs(intent Intent, value []byte) *IntentMessage {
+	return &IntentMessage{Intent: intent, Value: value}
+}
+
+func NewTransactionIntentMessage(txBytes []byte) *IntentMessage {
+	return NewIntentMessageFromBcs(TransactionDataIntent(), txBytes)
+}
+
+// NewPersonalIntentMessage wraps message as vector<u8>
+func NewPersonalIntentMessage(message []byte) *IntentMessage {
+	e := bcs.NewEncoder()
+	e.WriteBytes(message)
+	return NewIntentMessageFromBcs(PersonalMessageIntent(), e.Bytes())
+}
+
+func (im *IntentMessage) MarshalBCS(e *bcs.Encoder) error {
+	e.WriteFixedBytes(im.Intent.Bytes())
+	e.WriteFixedBytes(im.Value)
+	return nil
+}
+
+func (im *IntentMessage) Bytes() []byte {
+	data := make([]byte, 0, 3+len(im.Value))
+	data = append(data, im.Intent.Bytes()...)
+	data = append(data, im.Value...)
+	return data
+}
+
+func (im *IntentMessage) Digest() [32]byte {
+	return blake2b.Sum256(im.Bytes())
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestIntentMessage(t *testing.T) {
+	cases := []struct {
+		name    string
+		message *IntentMessage
+		bytes   string
+		digest  string
+	}{
+		{
+			name:    "transaction",
+			message: NewTransactionIntentMessage([]byte{0, 1, 2, 3, 4, 5}),
+			bytes:   "000000000102030405",
+			digest:  "eac95bc8b96889c9cc030d7d86ed74668b2ade991f47c2883edfe4ba70372001",
+		},
+		{
+			name:    "personal message",
+			message: NewPersonalIntentMessage([]byte("hello")),
+			bytes:   "0300000568656c6c6f",
+			digest:  "e0ea06e183a8984cd8dd072440ae2a8c21125d994a9435b7c8c61886bc087d6a",
+		},
+	}
+	for _, c := range cases {
+		if bytes := hex.EncodeToString(c.message.Bytes()); bytes != c.bytes {
+			t.Fatalf("%v: bytes %v, expect %v", c.name, bytes, c.bytes)
+		}
+		digest := c.message.Digest()
+		if hex.EncodeToString(digest[:]) != c.digest {
+			t.Fatalf("%v: digest %x, expect %v", c.name, digest, c.digest)
+		}
+	}
+	if hex.EncodeToString(IntentFlag) != "000000" {
+		t.Fatalf("unexpected intent flag: %x", IntentFlag)
+	}
+}
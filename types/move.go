@@ -0,0 +1,191 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"math/big"
+	"strings"
+)
+
+// Address is a move address or object::ID, 32 bytes in bcs and a hex string in json
+type Address string
+
+func (a Address) MarshalBCS(e *bcs.Encoder) error {
+	data, err := hex.DecodeString(strings.TrimPrefix(NormalizeAddress(string(a)), "0x"))
+	if err != nil {
+		return err
+	}
+	if len(data) != 32 {
+		return fmt.Errorf("invalid address: %v", a)
+	}
+	e.WriteFixedBytes(data)
+	return nil
+}
+
+func (a *Address) UnmarshalBCS(d *bcs.Decoder) error {
+	data, err := d.ReadFixedBytes(32)
+	if err != nil {
+		return err
+	}
+	*a = Address(fmt.Sprintf("0x%x", data))
+	return nil
+}
+
+func (a Address) String() string {
+	return string(a)
+}
+
+// UID is object::UID, rendered as {"id": "0x..."} in json
+type UID struct {
+	ID Address `json:"id"`
+}
+
+// MoveU64 accepts u64 values rendered as json strings, e.g. Balance<T>
+type MoveU64 uint64
+
+func (mu *MoveU64) UnmarshalJSON(data []byte) error {
+	var value json.Number
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	number, ok := new(big.Int).SetString(value.String(), 10)
+	if !ok || !number.IsUint64() {
+		return fmt.Errorf("invalid u64: %v", value)
+	}
+	*mu = MoveU64(number.Uint64())
+	return nil
+}
+
+func (mu MoveU64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%v", uint64(mu)))
+}
+
+// BigInt accepts u128 and u256 values rendered as json strings, use *big.Int with a bcs tag for bcs
+type BigInt struct {
+	big.Int
+}
+
+func (bi *BigInt) UnmarshalJSON(data []byte) error {
+	var value json.Number
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if _, ok := bi.SetString(value.String(), 10); !ok {
+		return fmt.Errorf("invalid integer: %v", value)
+	}
+	return nil
+}
+
+func (bi BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bi.String())
+}
+
+// NormalizeMoveFields unwraps the {"type": ..., "fields": {...}} form of nested structs
+// and Option<T> rendered as {"vec": [...]}, so fields map onto plain go structs.
+func NormalizeMoveFields(fields json.RawMessage) (json.RawMessage, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(fields))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalizeMoveValue(value))
+}
+
+func normalizeMoveValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeMoveValue(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		moveType, hasType := v["type"].(string)
+		fields, hasFields := v["fields"]
+		// a user struct may have its own type and fields members, only unwrap a real struct tag
+		if hasType && hasFields && len(v) == 2 && isStructTag(moveType) {
+			if strings.HasPrefix(NormalizeType(moveType), NormalizeType("0x1::option::Option<")) {
+				if structFields, ok := fields.(map[string]interface{}); ok {
+					if vec, ok := structFields["vec"].([]interface{}); ok {
+						if len(vec) == 0 {
+							return nil
+						}
+						return normalizeMoveValue(vec[0])
+					}
+				}
+			}
+			return normalizeMoveValue(fields)
+		}
+		for key := range v {
+			v[key] = normalizeMoveValue(v[key])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isStructTag reports whether moveType is address::module::Name with optional type parameters
+func isStructTag(moveType string) bool {
+	name := moveType
+	if index := strings.Index(moveType, "<"); index >= 0 {
+		name = moveType[:index]
+	}
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "0x") || !isMoveIdentifier(parts[1]) || !isMoveIdentifier(parts[2]) {
+		return false
+	}
+	return writeStructTag(bcs.NewEncoder(), moveType) == nil
+}
+
+func isMoveIdentifier(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i, c := range value {
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// DecodeMoveFields decodes the content fields of a move object into value.
+// Nested structs map onto go structs, UID onto UID, Option<T> onto a pointer, vector<T> onto a slice;
+// u64 (Balance<T>) and larger integers are json strings, use MoveU64, BigInt or a `json:",string"` tag.
+func DecodeMoveFields(fields json.RawMessage, value interface{}) error {
+	normalized, err := NormalizeMoveFields(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, value)
+}
+
+// DecodeFields decodes the move struct fields, the object must be read with showContent
+func (o *Object) DecodeFields(value interface{}) error {
+	if o.Content == nil || !o.Content.IsMoveObject() {
+		return fmt.Errorf("object %v has no move content, read it with showContent", o.ObjectID)
+	}
+	return DecodeMoveFields(o.Content.Fields, value)
+}
+
+// DecodeBcs decodes the move struct bcs bytes, the object must be read with showBcs.
+// Go fields must follow the move declaration order: UID, Address for ID and address, uint64 for Balance<T>,
+// pointers for Option<T>, slices for vector<T>, string for String and *big.Int tagged `bcs:"u256"` for u256.
+func (o *Object) DecodeBcs(value interface{}) error {
+	if o.Bcs == nil || o.Bcs.DataType != MoveObjectDataType {
+		return fmt.Errorf("object %v has no move bcs bytes, read it with showBcs", o.ObjectID)
+	}
+	data, err := o.Bcs.Bytes()
+	if err != nil {
+		return err
+	}
+	return bcs.Unmarshal(data, value)
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testPool struct {
+	ID      UID      `json:"id"`
+	Owner   Address  `json:"owner"`
+	Balance MoveU64  `json:"balance"`
+	Name    string   `json:"name"`
+	Limit   *MoveU64 `json:"limit"`
+	Tags    []string `json:"tags"`
+	Config  struct {
+		Fee  MoveU64 `json:"fee"`
+		Type string  `json:"type"`
+	} `json:"config"`
+	Meta map[string]interface{} `json:"meta"`
+}
+
+func TestDecodeMoveFields(t *testing.T) {
+	fields := json.RawMessage(`{
+		"id": {"id": "0xcf99111111111111111111111111111111111111111111111111111111111111"},
+		"owner": "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e",
+		"balance": "2500000",
+		"name": "pool",
+		"limit": {"type": "0x1::option::Option<u64>", "fields": {"vec": ["7"]}},
+		"tags": ["a", "b"],
+		"config": {"type": "0x2::pool::Config", "fields": {"fee": "30", "type": "stable"}},
+		"meta": {"type": "custom", "fields": "user data"}
+	}`)
+	var pool testPool
+	err := DecodeMoveFields(fields, &pool)
+	if err != nil {
+		panic(err)
+	}
+	if pool.ID.ID != "0xcf99111111111111111111111111111111111111111111111111111111111111" || pool.Balance != 2500000 || pool.Name != "pool" {
+		t.Fatalf("unexpected pool: %+v", pool)
+	}
+	if pool.Limit == nil || *pool.Limit != 7 || len(pool.Tags) != 2 {
+		t.Fatalf("unexpected limit or tags: %+v", pool)
+	}
+	if pool.Config.Fee != 30 || pool.Config.Type != "stable" {
+		t.Fatalf("unexpected config: %+v", pool.Config)
+	}
+	// "custom" is not a struct tag, so the user's own type and fields members are kept
+	if pool.Meta["type"] != "custom" || pool.Meta["fields"] != "user data" {
+		t.Fatalf("unexpected meta: %+v", pool.Meta)
+	}
+
+	fields = json.RawMessage(`{"limit": {"type": "0x1::option::Option<u64>", "fields": {"vec": []}}}`)
+	pool = testPool{}
+	err = DecodeMoveFields(fields, &pool)
+	if err != nil {
+		panic(err)
+	}
+	if pool.Limit != nil {
+		t.Fatalf("expect none limit, got %v", *pool.Limit)
+	}
+}
+
+func TestNormalizeMoveFields(t *testing.T) {
+	cases := map[string]string{
+		`{"a": {"type": "0x2::pool::Config", "fields": {"fee": "30"}}}`:                           `{"a":{"fee":"30"}}`,
+		`{"a": {"type": "0x2::coin::Coin<0x2::sui::SUI>", "fields": {"balance": "1"}}}`:           `{"a":{"balance":"1"}}`,
+		`{"a": {"type": "custom", "fields": {"fee": "30"}}}`:                                      `{"a":{"fields":{"fee":"30"},"type":"custom"}}`,
+		`{"a": {"type": "0x2::pool", "fields": {"fee": "30"}}}`:                                   `{"a":{"fields":{"fee":"30"},"type":"0x2::pool"}}`,
+		`{"a": {"type": "0x2::pool::1Config", "fields": {"fee": "30"}}}`:                          `{"a":{"fields":{"fee":"30"},"type":"0x2::pool::1Config"}}`,
+		`{"a": {"type": "0x2::pool::Config", "fields": {"fee": "30"}, "extra": true}}`:            `{"a":{"extra":true,"fields":{"fee":"30"},"type":"0x2::pool::Config"}}`,
+		`{"a": [{"type": "0x2::pool::Config", "fields": {"fee": "30"}}]}`:                         `{"a":[{"fee":"30"}]}`,
+		`{"a": {"type": "0x2::pool::Config<0x2::sui::SUI", "fields": {"fee": "30"}}}`:             `{"a":{"fields":{"fee":"30"},"type":"0x2::pool::Config\u003c0x2::sui::SUI"}}`,
+		`{"a": {"type": "0x1::option::Option<u64>", "fields": {"vec": ["7"]}}, "b": {"vec": []}}`: `{"a":"7","b":{"vec":[]}}`,
+	}
+	for fields, expect := range cases {
+		normalized, err := NormalizeMoveFields(json.RawMessage(fields))
+		if err != nil {
+			panic(err)
+		}
+		if string(normalized) != expect {
+			t.Fatalf("%v: got %s, expect %v", fields, normalized, expect)
+		}
+	}
+}
+
+func TestObjectDecodeBcs(t *testing.T) {
+	type pool struct {
+		ID      UID
+		Owner   Address
+		Balance uint64
+		Name    string
+		Limit   *uint64
+		Tags    []uint64
+	}
+	object := &Object{
+		ObjectID: "0xcf99111111111111111111111111111111111111111111111111111111111111",
+		Bcs: &ObjectBcsBytes{
+			DataType: MoveObjectDataType,
+			Type:     "0x2::pool::Pool",
+			BcsBytes: "z5kRERERERERERERERERERERERERERERERERERERERGikJ3TVa6v/VIIR6Ndoe9SnEzr00i7TWnwDzIxURgQbqAlJgAAAAAABHBvb2wBBwAAAAAAAAACAQAAAAAAAAACAAAAAAAAAA==",
+		},
+	}
+	var value pool
+	err := object.DecodeBcs(&value)
+	if err != nil {
+		panic(err)
+	}
+	if value.ID.ID.String() != object.ObjectID || value.Owner.String() != "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e" {
+		t.Fatalf("unexpected id or owner: %+v", value)
+	}
+	if value.Balance != 2500000 || value.Name != "pool" || value.Limit == nil || *value.Limit != 7 || len(value.Tags) != 2 || value.Tags[1] != 2 {
+		t.Fatalf("unexpected pool: %+v", value)
+	}
+
+	err = (&Object{ObjectID: object.ObjectID}).DecodeBcs(&value)
+	if err == nil {
+		t.Fatalf("expect error without bcs bytes")
+	}
+	object.Bcs.BcsBytes = object.Bcs.BcsBytes[:40]
+	err = object.DecodeBcs(&value)
+	if err == nil {
+		t.Fatalf("expect error for truncated bcs bytes")
+	}
+}
@@ -1,12 +1,213 @@
 package types
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
 type Object struct {
-	ObjectID string `json:"objectId"`
-	Version  string `json:"version"`
-	Digest   string `json:"digest"`
+	ObjectID            string          `json:"objectId"`
+	Version             string          `json:"version"`
+	Digest              string          `json:"digest"`
+	Type                string          `json:"type,omitempty"`
+	Owner               Owner           `json:"owner"`
+	PreviousTransaction string          `json:"previousTransaction,omitempty"`
+	StorageRebate       string          `json:"storageRebate,omitempty"`
+	Display             *DisplayFields  `json:"display,omitempty"`
+	Content             *ObjectContent  `json:"content,omitempty"`
+	Bcs                 *ObjectBcsBytes `json:"bcs,omitempty"`
+}
+
+type ObjectData = Object
+
+const (
+	MoveObjectDataType = "moveObject"
+	PackageDataType    = "package"
+)
+
+// ObjectContent is returned with showContent, Fields holds the move struct fields of a moveObject
+type ObjectContent struct {
+	DataType          string            `json:"dataType"`
+	Type              string            `json:"type,omitempty"`
+	HasPublicTransfer bool              `json:"hasPublicTransfer,omitempty"`
+	Fields            json.RawMessage   `json:"fields,omitempty"`
+	Disassembled      map[string]string `json:"disassembled,omitempty"`
+}
+
+func (oc *ObjectContent) IsMoveObject() bool {
+	return oc.DataType == MoveObjectDataType
+}
+
+// FieldsMap returns the fields as generic json values, nested structs keep their {"type","fields"} form
+func (oc *ObjectContent) FieldsMap() (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if len(oc.Fields) == 0 {
+		return result, nil
+	}
+	err := json.Unmarshal(oc.Fields, &result)
+	return result, err
+}
+
+// ObjectBcsBytes is returned with showBcs, BcsBytes is set for a moveObject and ModuleMap for a package
+type ObjectBcsBytes struct {
+	DataType          string            `json:"dataType"`
+	Type              string            `json:"type,omitempty"`
+	HasPublicTransfer bool              `json:"hasPublicTransfer,omitempty"`
+	Version           json.Number       `json:"version,omitempty"`
+	BcsBytes          string            `json:"bcsBytes,omitempty"`
+	ID                string            `json:"id,omitempty"`
+	ModuleMap         map[string]string `json:"moduleMap,omitempty"`
+}
+
+func (ob *ObjectBcsBytes) Bytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(ob.BcsBytes)
 }
+
+// DisplayFields is returned with showDisplay, Data holds the rendered display template
+type DisplayFields struct {
+	Data  map[string]string    `json:"data"`
+	Error *ObjectResponseError `json:"error"`
+}
+
 type ObjData struct {
-	Data Object `json:"data"`
+	Data  Object               `json:"data"`
+	Error *ObjectResponseError `json:"error,omitempty"`
+}
+
+const (
+	ObjectNotExistsCode      = "notExists"
+	ObjectDeletedCode        = "deleted"
+	DynamicFieldNotFoundCode = "dynamicFieldNotFound"
+	DisplayErrorCode         = "displayError"
+	UnknownObjectErrorCode   = "unknown"
+)
+
+type ObjectResponseError struct {
+	Code           string      `json:"code"`
+	ObjectID       string      `json:"object_id,omitempty"`
+	ParentObjectID string      `json:"parent_object_id,omitempty"`
+	Version        json.Number `json:"version,omitempty"`
+	Digest         string      `json:"digest,omitempty"`
+	Message        string      `json:"error,omitempty"`
+}
+
+func (ore *ObjectResponseError) Error() string {
+	return fmt.Sprintf("object %v error: %v %v", ore.ObjectID, ore.Code, ore.Message)
+}
+
+// Err converts the response error into ObjectNotExistsError, ObjectDeletedError or DynamicFieldNotFoundError
+func (ore *ObjectResponseError) Err() error {
+	switch ore.Code {
+	case ObjectNotExistsCode:
+		return &ObjectNotExistsError{ObjectID: ore.ObjectID}
+	case ObjectDeletedCode:
+		return &ObjectDeletedError{ObjectID: ore.ObjectID, Version: ore.Version.String(), Digest: ore.Digest}
+	case DynamicFieldNotFoundCode:
+		return &DynamicFieldNotFoundError{ParentObjectID: ore.ParentObjectID}
+	default:
+		return ore
+	}
+}
+
+type ObjectNotExistsError struct {
+	ObjectID string
+}
+
+func (e *ObjectNotExistsError) Error() string {
+	return fmt.Sprintf("object not exists: %v", e.ObjectID)
+}
+
+type ObjectDeletedError struct {
+	ObjectID string
+	Version  string
+	Digest   string
+}
+
+func (e *ObjectDeletedError) Error() string {
+	return fmt.Sprintf("object deleted: %v version %v", e.ObjectID, e.Version)
+}
+
+type ObjectVersionNotFoundError struct {
+	ObjectID string
+	Version  string
+}
+
+func (e *ObjectVersionNotFoundError) Error() string {
+	return fmt.Sprintf("object %v version not found: %v", e.ObjectID, e.Version)
+}
+
+type ObjectVersionTooHighError struct {
+	ObjectID      string
+	AskedVersion  string
+	LatestVersion string
+}
+
+func (e *ObjectVersionTooHighError) Error() string {
+	return fmt.Sprintf("object %v version %v too high, latest %v", e.ObjectID, e.AskedVersion, e.LatestVersion)
+}
+
+type DynamicFieldNotFoundError struct {
+	ParentObjectID string
+}
+
+func (e *DynamicFieldNotFoundError) Error() string {
+	return fmt.Sprintf("dynamic field not found under: %v", e.ParentObjectID)
+}
+
+const (
+	VersionFoundStatus    = "VersionFound"
+	ObjectNotExistsStatus = "ObjectNotExists"
+	ObjectDeletedStatus   = "ObjectDeleted"
+	VersionNotFoundStatus = "VersionNotFound"
+	VersionTooHighStatus  = "VersionTooHigh"
+)
+
+// PastObject is the sui_tryGetPastObject response, Details depends on Status
+type PastObject struct {
+	Status  string          `json:"status"`
+	Details json.RawMessage `json:"details"`
+}
+
+// Object returns the object when Status is VersionFound and a typed error otherwise
+func (po *PastObject) Object(objectId string, version uint64) (*Object, error) {
+	switch po.Status {
+	case VersionFoundStatus:
+		result := &Object{}
+		err := json.Unmarshal(po.Details, result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case ObjectNotExistsStatus:
+		return nil, &ObjectNotExistsError{ObjectID: objectId}
+	case ObjectDeletedStatus:
+		ref := struct {
+			ObjectID string      `json:"objectId"`
+			Version  json.Number `json:"version"`
+			Digest   string      `json:"digest"`
+		}{}
+		err := json.Unmarshal(po.Details, &ref)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &ObjectDeletedError{ObjectID: ref.ObjectID, Version: ref.Version.String(), Digest: ref.Digest}
+	case VersionNotFoundStatus:
+		return nil, &ObjectVersionNotFoundError{ObjectID: objectId, Version: fmt.Sprintf("%v", version)}
+	case VersionTooHighStatus:
+		detail := struct {
+			AskedVersion  json.Number `json:"asked_version"`
+			LatestVersion json.Number `json:"latest_version"`
+		}{}
+		err := json.Unmarshal(po.Details, &detail)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &ObjectVersionTooHighError{ObjectID: objectId, AskedVersion: detail.AskedVersion.String(), LatestVersion: detail.LatestVersion.String()}
+	default:
+		return nil, fmt.Errorf("unknown past object status: %v", po.Status)
+	}
 }
 
 type ObjectInfo struct {
@@ -25,6 +226,19 @@ type CoinData struct {
 	PreviousTransaction string      `json:"previousTransaction"`
 }
 
+func (cd *CoinData) BigBalance() (*big.Int, error) {
+	balanceBig, ok := big.NewInt(0).SetString(cd.Balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("parse big error: %v", cd.Balance)
+	}
+	return balanceBig, nil
+}
+
+// IsLocked reports a coin locked until an epoch, which can not be spent
+func (cd *CoinData) IsLocked() bool {
+	return cd.LockedUntilEpoch != nil
+}
+
 type CoinObj struct {
 	Data        []CoinData `json:"data"`
 	NextCursor  string     `json:"nextCursor"`
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPastObject(t *testing.T) {
+	responses := map[string]*PastObject{}
+	loadFixture(t, "past_objects.json", &responses)
+	objectId := "0xc8395ecc3d31845032b541cb0bbaad82cb32434187b3eb465466473c10a28d8c"
+
+	object, err := responses["versionFound"].Object(objectId, 41)
+	if err != nil {
+		panic(err)
+	}
+	if object.ObjectID != objectId || object.Version != "41" || object.Owner.Address() != "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e" {
+		t.Fatalf("unexpected object: %+v", object)
+	}
+
+	_, err = responses["objectDeleted"].Object("0xd9b73054448d84cd282b501b31b509164c4ac3e6568d9323e740be19a35faff2", 42)
+	var deleted *ObjectDeletedError
+	if !errors.As(err, &deleted) || deleted.Version != "42" || deleted.Digest != "7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz" {
+		t.Fatalf("unexpected deleted error: %v", err)
+	}
+
+	_, err = responses["versionNotFound"].Object(objectId, 40)
+	var notFound *ObjectVersionNotFoundError
+	if !errors.As(err, &notFound) || notFound.ObjectID != objectId || notFound.Version != "40" {
+		t.Fatalf("unexpected version not found error: %v", err)
+	}
+
+	_, err = responses["versionTooHigh"].Object(objectId, 50)
+	var tooHigh *ObjectVersionTooHighError
+	if !errors.As(err, &tooHigh) || tooHigh.AskedVersion != "50" || tooHigh.LatestVersion != "42" {
+		t.Fatalf("unexpected version too high error: %v", err)
+	}
+
+	_, err = responses["objectNotExists"].Object("0x42", 1)
+	var notExists *ObjectNotExistsError
+	if !errors.As(err, &notExists) || notExists.ObjectID != "0x42" {
+		t.Fatalf("unexpected not exists error: %v", err)
+	}
+
+	if _, err = (&PastObject{Status: "VersionPruned"}).Object(objectId, 1); err == nil {
+		t.Fatalf("expect error for unknown status")
+	}
+}
+
+func TestObjectResponseError(t *testing.T) {
+	responses := map[string]*ObjData{}
+	loadFixture(t, "object_errors.json", &responses)
+
+	var notExists *ObjectNotExistsError
+	if err := responses["notExists"].Error.Err(); !errors.As(err, &notExists) ||
+		notExists.ObjectID != "0x0000000000000000000000000000000000000000000000000000000000000042" {
+		t.Fatalf("unexpected not exists error: %v", err)
+	}
+	var deleted *ObjectDeletedError
+	if err := responses["deleted"].Error.Err(); !errors.As(err, &deleted) || deleted.Version != "42" {
+		t.Fatalf("unexpected deleted error: %v", err)
+	}
+	var fieldNotFound *DynamicFieldNotFoundError
+	if err := responses["dynamicFieldNotFound"].Error.Err(); !errors.As(err, &fieldNotFound) ||
+		fieldNotFound.ParentObjectID != "0xbd49f21a93f0628429378663ef2fe800526c1c5a9f6fd484555cefe0bb2e7412" {
+		t.Fatalf("unexpected dynamic field error: %v", err)
+	}
+	// other codes stay the response error
+	display := responses["displayError"]
+	var responseErr *ObjectResponseError
+	if err := display.Error.Err(); !errors.As(err, &responseErr) || responseErr.Message != "display template not found" {
+		t.Fatalf("unexpected display error: %v", err)
+	}
+	if display.Data.Version != "42" {
+		t.Fatalf("unexpected object: %+v", display.Data)
+	}
+}
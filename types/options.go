@@ -0,0 +1,60 @@
+package types
+
+type TransactionBlockResponseOptions struct {
+	ShowInput          bool `json:"showInput"`
+	ShowRawInput       bool `json:"showRawInput"`
+	ShowEffects        bool `json:"showEffects"`
+	ShowEvents         bool `json:"showEvents"`
+	ShowObjectChanges  bool `json:"showObjectChanges"`
+	ShowBalanceChanges bool `json:"showBalanceChanges"`
+}
+
+// DefaultTransactionBlockResponseOptions is what TransactionBlock.Parse needs, used when options is nil.
+// CreatedObjectsOfType and PublishedPackageID need ShowObjectChanges on top of it.
+func DefaultTransactionBlockResponseOptions() *TransactionBlockResponseOptions {
+	return &TransactionBlockResponseOptions{
+		ShowInput:          true,
+		ShowEffects:        true,
+		ShowBalanceChanges: true,
+	}
+}
+
+func FullTransactionBlockResponseOptions() *TransactionBlockResponseOptions {
+	return &TransactionBlockResponseOptions{
+		ShowInput:          true,
+		ShowRawInput:       true,
+		ShowEffects:        true,
+		ShowEvents:         true,
+		ShowObjectChanges:  true,
+		ShowBalanceChanges: true,
+	}
+}
+
+type ObjectDataOptions struct {
+	ShowType                bool `json:"showType"`
+	ShowOwner               bool `json:"showOwner"`
+	ShowPreviousTransaction bool `json:"showPreviousTransaction"`
+	ShowDisplay             bool `json:"showDisplay"`
+	ShowContent             bool `json:"showContent"`
+	ShowBcs                 bool `json:"showBcs"`
+	ShowStorageRebate       bool `json:"showStorageRebate"`
+}
+
+func DefaultObjectDataOptions() *ObjectDataOptions {
+	return &ObjectDataOptions{
+		ShowType:  true,
+		ShowOwner: true,
+	}
+}
+
+func FullObjectDataOptions() *ObjectDataOptions {
+	return &ObjectDataOptions{
+		ShowType:                true,
+		ShowOwner:               true,
+		ShowPreviousTransaction: true,
+		ShowDisplay:             true,
+		ShowContent:             true,
+		ShowBcs:                 true,
+		ShowStorageRebate:       true,
+	}
+}
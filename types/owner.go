@@ -0,0 +1,165 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type OwnerKind string
+
+const (
+	AddressOwnerKind          OwnerKind = "AddressOwner"
+	ObjectOwnerKind           OwnerKind = "ObjectOwner"
+	SharedOwnerKind           OwnerKind = "Shared"
+	ImmutableOwnerKind        OwnerKind = "Immutable"
+	ConsensusAddressOwnerKind OwnerKind = "ConsensusAddressOwner"
+)
+
+func (ok OwnerKind) String() string {
+	return string(ok)
+}
+
+type SharedOwner struct {
+	InitialSharedVersion uint64 `json:"initial_shared_version"`
+}
+
+type ConsensusAddressOwner struct {
+	StartVersion uint64 `json:"start_version"`
+	Owner        string `json:"owner"`
+}
+
+// Owner is one of the owner variants, Kind tells which field is set.
+// Kind is empty when the node returned no owner. Variants unknown to this sdk keep their json in Raw.
+type Owner struct {
+	Kind                  OwnerKind
+	AddressOwner          string
+	ObjectOwner           string
+	Shared                *SharedOwner
+	ConsensusAddressOwner *ConsensusAddressOwner
+	Raw                   json.RawMessage
+}
+
+func (o *Owner) UnmarshalJSON(data []byte) error {
+	*o = Owner{}
+	if string(data) == "null" {
+		return nil
+	}
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		o.Kind = OwnerKind(kind)
+		if o.Kind != ImmutableOwnerKind {
+			o.Raw = append(json.RawMessage{}, data...)
+		}
+		return nil
+	}
+	var variants map[OwnerKind]json.RawMessage
+	err := json.Unmarshal(data, &variants)
+	if err != nil {
+		return err
+	}
+	if len(variants) != 1 {
+		return fmt.Errorf("unknown owner: %v", string(data))
+	}
+	for kind, value := range variants {
+		o.Kind = kind
+		switch kind {
+		case AddressOwnerKind:
+			err = json.Unmarshal(value, &o.AddressOwner)
+		case ObjectOwnerKind:
+			err = json.Unmarshal(value, &o.ObjectOwner)
+		case SharedOwnerKind:
+			o.Shared = &SharedOwner{}
+			err = json.Unmarshal(value, o.Shared)
+		case ConsensusAddressOwnerKind:
+			o.ConsensusAddressOwner = &ConsensusAddressOwner{}
+			err = json.Unmarshal(value, o.ConsensusAddressOwner)
+		default:
+			o.Raw = append(json.RawMessage{}, data...)
+		}
+	}
+	return err
+}
+
+func (o Owner) MarshalJSON() ([]byte, error) {
+	switch o.Kind {
+	case "":
+		return []byte("null"), nil
+	case ImmutableOwnerKind:
+		return json.Marshal(ImmutableOwnerKind)
+	case AddressOwnerKind:
+		return json.Marshal(map[OwnerKind]string{o.Kind: o.AddressOwner})
+	case ObjectOwnerKind:
+		return json.Marshal(map[OwnerKind]string{o.Kind: o.ObjectOwner})
+	case SharedOwnerKind:
+		return json.Marshal(map[OwnerKind]*SharedOwner{o.Kind: o.Shared})
+	case ConsensusAddressOwnerKind:
+		return json.Marshal(map[OwnerKind]*ConsensusAddressOwner{o.Kind: o.ConsensusAddressOwner})
+	default:
+		if o.Raw != nil {
+			return o.Raw, nil
+		}
+		return nil, fmt.Errorf("unknown owner kind: %v", o.Kind)
+	}
+}
+
+// Address returns the owning address, or the parent object id for ObjectOwner,
+// it is empty for shared and immutable objects.
+func (o Owner) Address() string {
+	switch o.Kind {
+	case AddressOwnerKind:
+		return o.AddressOwner
+	case ObjectOwnerKind:
+		return o.ObjectOwner
+	case ConsensusAddressOwnerKind:
+		if o.ConsensusAddressOwner == nil {
+			return ""
+		}
+		return o.ConsensusAddressOwner.Owner
+	default:
+		return ""
+	}
+}
+
+func (o Owner) IsAddressOwner() bool {
+	return o.Kind == AddressOwnerKind
+}
+
+func (o Owner) IsObjectOwner() bool {
+	return o.Kind == ObjectOwnerKind
+}
+
+func (o Owner) IsShared() bool {
+	return o.Kind == SharedOwnerKind
+}
+
+func (o Owner) IsImmutable() bool {
+	return o.Kind == ImmutableOwnerKind
+}
+
+func (o Owner) IsConsensusAddressOwner() bool {
+	return o.Kind == ConsensusAddressOwnerKind
+}
+
+// InitialSharedVersion is false for other kinds and for a Shared owner built without its version
+func (o Owner) InitialSharedVersion() (uint64, bool) {
+	if o.Kind != SharedOwnerKind || o.Shared == nil {
+		return 0, false
+	}
+	return o.Shared.InitialSharedVersion, true
+}
+
+func (o Owner) String() string {
+	switch o.Kind {
+	case SharedOwnerKind:
+		if o.Shared == nil {
+			return "Shared(?)"
+		}
+		return fmt.Sprintf("Shared(%v)", o.Shared.InitialSharedVersion)
+	case ImmutableOwnerKind:
+		return ImmutableOwnerKind.String()
+	case "":
+		return ""
+	default:
+		return fmt.Sprintf("%v(%v)", o.Kind, o.Address())
+	}
+}
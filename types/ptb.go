@@ -0,0 +1,85 @@
+package types
+
+import "fmt"
+
+// ProgrammableTransactionBuilder collects the inputs and commands of a programmable transaction,
+// each method returns the argument referring to what it added. Object inputs are deduplicated by id.
+type ProgrammableTransactionBuilder struct {
+	inputs   []CallArg
+	commands []Command
+	objects  map[string]uint16
+}
+
+func NewProgrammableTransactionBuilder() *ProgrammableTransactionBuilder {
+	return &ProgrammableTransactionBuilder{objects: map[string]uint16{}}
+}
+
+func (b *ProgrammableTransactionBuilder) Input(arg CallArg) Argument {
+	if arg.Object != nil {
+		id := NormalizeAddress(arg.Object.ObjectID().String())
+		if index, ok := b.objects[id]; ok {
+			return InputArgument(index)
+		}
+		b.objects[id] = uint16(len(b.inputs))
+	}
+	b.inputs = append(b.inputs, arg)
+	return InputArgument(uint16(len(b.inputs) - 1))
+}
+
+// Pure adds a bcs serialized value, e.g. uint64 for u64 and Address for address
+func (b *ProgrammableTransactionBuilder) Pure(value interface{}) (Argument, error) {
+	arg, err := PureCallArg(value)
+	if err != nil {
+		return Argument{}, fmt.Errorf("pure input %v error: %v", value, err)
+	}
+	return b.Input(arg), nil
+}
+
+func (b *ProgrammableTransactionBuilder) Object(arg ObjectArg) Argument {
+	return b.Input(ObjectCallArg(arg))
+}
+
+func (b *ProgrammableTransactionBuilder) OwnedObject(ref ObjectRef) Argument {
+	return b.Object(ObjectArg{ImmOrOwned: &ref})
+}
+
+func (b *ProgrammableTransactionBuilder) SharedObject(ref SharedObjectRef) Argument {
+	return b.Object(ObjectArg{Shared: &ref})
+}
+
+// Command adds command and returns its result
+func (b *ProgrammableTransactionBuilder) Command(command Command) Argument {
+	b.commands = append(b.commands, command)
+	return ResultArgument(uint16(len(b.commands) - 1))
+}
+
+func (b *ProgrammableTransactionBuilder) MoveCall(packageId, module, function string, typeArguments []string, arguments []Argument) Argument {
+	return b.Command(Command{MoveCall: &MoveCallCommand{
+		Package:       packageId,
+		Module:        module,
+		Function:      function,
+		TypeArguments: typeArguments,
+		Arguments:     arguments,
+	}})
+}
+
+func (b *ProgrammableTransactionBuilder) TransferObjects(objects []Argument, address Argument) Argument {
+	return b.Command(Command{TransferObjects: &TransferObjectsCommand{Objects: objects, Address: address}})
+}
+
+// SplitCoins returns the result holding the new coins, use NestedResultArgument to refer to each
+func (b *ProgrammableTransactionBuilder) SplitCoins(coin Argument, amounts []Argument) Argument {
+	return b.Command(Command{SplitCoins: &SplitCoinsCommand{Coin: coin, Amounts: amounts}})
+}
+
+func (b *ProgrammableTransactionBuilder) MergeCoins(destination Argument, sources []Argument) Argument {
+	return b.Command(Command{MergeCoins: &MergeCoinsCommand{Destination: destination, Sources: sources}})
+}
+
+func (b *ProgrammableTransactionBuilder) MakeMoveVec(moveType *string, elements []Argument) Argument {
+	return b.Command(Command{MakeMoveVec: &MakeMoveVecCommand{Type: moveType, Elements: elements}})
+}
+
+func (b *ProgrammableTransactionBuilder) Finish() ProgrammableTransaction {
+	return ProgrammableTransaction{Inputs: b.inputs, Commands: b.commands}
+}
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"fmt"
+)
+
+type FromAndToAddress struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type MoveFunctionFilter struct {
+	Package  string  `json:"package"`
+	Module   *string `json:"module,omitempty"`
+	Function *string `json:"function,omitempty"`
+}
+
+// TransactionFilter holds exactly one filter, build it with the *Filter constructors
+type TransactionFilter struct {
+	FromAddress      string              `json:"FromAddress,omitempty"`
+	ToAddress        string              `json:"ToAddress,omitempty"`
+	FromAndToAddress *FromAndToAddress   `json:"FromAndToAddress,omitempty"`
+	InputObject      string              `json:"InputObject,omitempty"`
+	ChangedObject    string              `json:"ChangedObject,omitempty"`
+	MoveFunction     *MoveFunctionFilter `json:"MoveFunction,omitempty"`
+	TransactionKind  string              `json:"TransactionKind,omitempty"`
+	Checkpoint       string              `json:"Checkpoint,omitempty"`
+}
+
+func FromAddressFilter(address string) *TransactionFilter {
+	return &TransactionFilter{FromAddress: address}
+}
+
+func ToAddressFilter(address string) *TransactionFilter {
+	return &TransactionFilter{ToAddress: address}
+}
+
+func FromAndToAddressFilter(from, to string) *TransactionFilter {
+	return &TransactionFilter{FromAndToAddress: &FromAndToAddress{From: from, To: to}}
+}
+
+func InputObjectFilter(objectId string) *TransactionFilter {
+	return &TransactionFilter{InputObject: objectId}
+}
+
+func ChangedObjectFilter(objectId string) *TransactionFilter {
+	return &TransactionFilter{ChangedObject: objectId}
+}
+
+// MoveFunctionCallFilter matches calls of package, module and function may be empty to match all
+func MoveFunctionCallFilter(packageId, module, function string) *TransactionFilter {
+	filter := &MoveFunctionFilter{Package: packageId}
+	if module != "" {
+		filter.Module = &module
+	}
+	if function != "" {
+		filter.Function = &function
+	}
+	return &TransactionFilter{MoveFunction: filter}
+}
+
+func TransactionKindFilter(kind TransactionKindType) *TransactionFilter {
+	return &TransactionFilter{TransactionKind: kind.String()}
+}
+
+func CheckpointFilter(checkpoint uint64) *TransactionFilter {
+	return &TransactionFilter{Checkpoint: fmt.Sprintf("%v", checkpoint)}
+}
+
+type TransactionBlockResponseQuery struct {
+	Filter  *TransactionFilter               `json:"filter,omitempty"`
+	Options *TransactionBlockResponseOptions `json:"options,omitempty"`
+}
+
+type TransactionBlocksPage struct {
+	Data        []TransactionBlock `json:"data"`
+	NextCursor  string             `json:"nextCursor"`
+	HasNextPage bool               `json:"hasNextPage"`
+}
+
+// ObjectFilter holds exactly one filter, build it with the *ObjectFilter constructors
+type ObjectFilter struct {
+	StructType   string            `json:"StructType,omitempty"`
+	Package      string            `json:"Package,omitempty"`
+	MoveModule   *MoveModuleFilter `json:"MoveModule,omitempty"`
+	ObjectId     string            `json:"ObjectId,omitempty"`
+	ObjectIds    *[]string         `json:"ObjectIds,omitempty"`
+	Version      string            `json:"Version,omitempty"`
+	AddressOwner string            `json:"AddressOwner,omitempty"`
+	ObjectOwner  string            `json:"ObjectOwner,omitempty"`
+	// the list filters are pointers like EventFilter.All, so an empty list is kept in the json
+	MatchAll  *[]ObjectFilter `json:"MatchAll,omitempty"`
+	MatchAny  *[]ObjectFilter `json:"MatchAny,omitempty"`
+	MatchNone *[]ObjectFilter `json:"MatchNone,omitempty"`
+}
+
+// StructTypeObjectFilter matches a struct type, without type parameters it matches every instantiation
+func StructTypeObjectFilter(structType string) *ObjectFilter {
+	return &ObjectFilter{StructType: structType}
+}
+
+func PackageObjectFilter(packageId string) *ObjectFilter {
+	return &ObjectFilter{Package: packageId}
+}
+
+func MoveModuleObjectFilter(packageId, module string) *ObjectFilter {
+	return &ObjectFilter{MoveModule: &MoveModuleFilter{Package: packageId, Module: module}}
+}
+
+func ObjectIdObjectFilter(objectId string) *ObjectFilter {
+	return &ObjectFilter{ObjectId: objectId}
+}
+
+func ObjectIdsObjectFilter(objectIds ...string) *ObjectFilter {
+	if objectIds == nil {
+		objectIds = []string{}
+	}
+	return &ObjectFilter{ObjectIds: &objectIds}
+}
+
+func VersionObjectFilter(version uint64) *ObjectFilter {
+	return &ObjectFilter{Version: fmt.Sprintf("%v", version)}
+}
+
+func MatchAllObjectFilter(filters ...ObjectFilter) *ObjectFilter {
+	return &ObjectFilter{MatchAll: objectFilterList(filters)}
+}
+
+func MatchAnyObjectFilter(filters ...ObjectFilter) *ObjectFilter {
+	return &ObjectFilter{MatchAny: objectFilterList(filters)}
+}
+
+func MatchNoneObjectFilter(filters ...ObjectFilter) *ObjectFilter {
+	return &ObjectFilter{MatchNone: objectFilterList(filters)}
+}
+
+func objectFilterList(filters []ObjectFilter) *[]ObjectFilter {
+	if filters == nil {
+		filters = []ObjectFilter{}
+	}
+	return &filters
+}
+
+type ObjectResponseQuery struct {
+	Filter  *ObjectFilter      `json:"filter,omitempty"`
+	Options *ObjectDataOptions `json:"options,omitempty"`
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestObjectFilter_JSON(t *testing.T) {
+	cases := map[string]*ObjectFilter{
+		`{"MatchAll":[]}`:  MatchAllObjectFilter(),
+		`{"MatchAny":[]}`:  MatchAnyObjectFilter(),
+		`{"MatchNone":[]}`: MatchNoneObjectFilter(),
+		`{"ObjectIds":[]}`: ObjectIdsObjectFilter(),
+		`{"MatchAll":[{"Package":"0x2"},{"MatchNone":[{"ObjectId":"0x6"}]}]}`: MatchAllObjectFilter(
+			*PackageObjectFilter("0x2"),
+			*MatchNoneObjectFilter(*ObjectIdObjectFilter("0x6")),
+		),
+	}
+	for expect, filter := range cases {
+		data, err := json.Marshal(filter)
+		if err != nil {
+			panic(err)
+		}
+		if string(data) != expect {
+			t.Fatalf("got %s, expect %v", data, expect)
+		}
+		var parsed ObjectFilter
+		err = json.Unmarshal(data, &parsed)
+		if err != nil {
+			panic(err)
+		}
+		if !reflect.DeepEqual(&parsed, filter) {
+			t.Fatalf("%v: filter does not roundtrip: %+v", expect, parsed)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package types
+
+// SuiSystemStateSummary is the part of suix_getLatestSuiSystemState the sdk uses
+type SuiSystemStateSummary struct {
+	Epoch                 string `json:"epoch"`
+	ProtocolVersion       string `json:"protocolVersion"`
+	SystemStateVersion    string `json:"systemStateVersion"`
+	ReferenceGasPrice     string `json:"referenceGasPrice"`
+	EpochStartTimestampMs string `json:"epochStartTimestampMs"`
+	EpochDurationMs       string `json:"epochDurationMs"`
+	SafeMode              bool   `json:"safeMode"`
+	TotalStake            string `json:"totalStake"`
+}
@@ -33,15 +33,16 @@ type Tx struct {
 }
 
 type TransactionBlock struct {
-	Digest      string      `json:"digest"`
-	Transaction Transaction `json:"transaction"`
-	//RawTransaction string           `json:"rawTransaction"`
-	Effects Effects `json:"effects"`
-	//Events         []interface{}    `json:"events"`
-	//ObjectChanges  []ObjectChanges  `json:"objectChanges"`
+	Digest         string           `json:"digest"`
+	Transaction    Transaction      `json:"transaction"`
+	RawTransaction string           `json:"rawTransaction"`
+	Effects        Effects          `json:"effects"`
+	Events         []TxEvent        `json:"events"`
+	ObjectChanges  []ObjectChanges  `json:"objectChanges"`
 	BalanceChanges []BalanceChanges `json:"balanceChanges"`
 	TimestampMs    string           `json:"timestampMs"`
 	Checkpoint     string           `json:"checkpoint"`
+	Errors         []string         `json:"errors"`
 }
 
 func (tb *TransactionBlock) Parse() ([]Tx, error) {
@@ -87,7 +88,7 @@ func (tb *TransactionBlock) Parse() ([]Tx, error) {
 			Gas:            gasUsed,
 			Epoch:          tb.Effects.ExecutedEpoch,
 			MessageVersion: tb.Effects.MessageVersion,
-			Recipient:      balChange.Owner.AddressOwner,
+			Recipient:      balChange.Owner.Address(),
 			Amount:         amountBig,
 			CoinType:       CoinType(balChange.CoinType),
 			Time:           txTimestamp,
@@ -97,6 +98,26 @@ func (tb *TransactionBlock) Parse() ([]Tx, error) {
 	return txList, nil
 }
 
+// CreatedObjectsOfType needs objectChanges, objectType may omit type parameters ("0x2::coin::Coin")
+func (tb *TransactionBlock) CreatedObjectsOfType(objectType string) []ObjectChanges {
+	var result []ObjectChanges
+	for _, item := range tb.ObjectChanges {
+		if item.Type == CreatedObjectChange && MatchType(item.ObjectType, objectType) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func (tb *TransactionBlock) PublishedPackageID() (string, bool) {
+	for _, item := range tb.ObjectChanges {
+		if item.Type == PublishedObjectChange {
+			return item.PackageID, true
+		}
+	}
+	return "", false
+}
+
 func (tb *TransactionBlock) Status() Status {
 	return tb.Effects.Status
 
@@ -132,23 +153,6 @@ func (tb *TransactionBlock) GetGasUsed() (*big.Int, error) {
 
 }
 
-type Inputs struct {
-	Type      string      `json:"type"`
-	ValueType string      `json:"valueType"`
-	Value     interface{} `json:"value"`
-}
-
-type Transactions struct {
-	SplitCoins      []interface{} `json:"SplitCoins,omitempty"`
-	TransferObjects []interface{} `json:"TransferObjects,omitempty"`
-}
-
-type DataTransaction struct {
-	Kind         string         `json:"kind"`
-	Inputs       []Inputs       `json:"inputs"`
-	Transactions []Transactions `json:"transactions"`
-}
-
 type Payment struct {
 	ObjectID string `json:"objectId"`
 	Version  int    `json:"version"`
@@ -163,10 +167,10 @@ type GasData struct {
 }
 
 type Data struct {
-	//MessageVersion string          `json:"messageVersion"`
-	//Transaction DataTransaction `json:"transaction"`
-	Sender  string  `json:"sender"`
-	GasData GasData `json:"gasData"`
+	MessageVersion string          `json:"messageVersion"`
+	Transaction    DataTransaction `json:"transaction"`
+	Sender         string          `json:"sender"`
+	GasData        GasData         `json:"gasData"`
 }
 
 type Transaction struct {
@@ -191,53 +195,90 @@ type ModifiedAtVersions struct {
 	SequenceNumber string `json:"sequenceNumber"`
 }
 
-type Owner struct {
-	AddressOwner string `json:"AddressOwner"`
-}
-
 type Reference struct {
 	ObjectID string `json:"objectId"`
 	Version  int    `json:"version"`
 	Digest   string `json:"digest"`
 }
 
-type Created struct {
+type OwnedObjectRef struct {
 	Owner     Owner     `json:"owner"`
 	Reference Reference `json:"reference"`
 }
 
-type Mutated struct {
-	Owner     Owner     `json:"owner"`
-	Reference Reference `json:"reference"`
+type Created = OwnedObjectRef
+
+type Mutated = OwnedObjectRef
+
+type GasObject = OwnedObjectRef
+
+type Effects struct {
+	MessageVersion       string               `json:"messageVersion"`
+	Status               Status               `json:"status"`
+	ExecutedEpoch        string               `json:"executedEpoch"`
+	GasUsed              GasUsed              `json:"gasUsed"`
+	ModifiedAtVersions   []ModifiedAtVersions `json:"modifiedAtVersions"`
+	SharedObjects        []Reference          `json:"sharedObjects"`
+	TransactionDigest    string               `json:"transactionDigest"`
+	Created              []Created            `json:"created"`
+	Mutated              []Mutated            `json:"mutated"`
+	Unwrapped            []OwnedObjectRef     `json:"unwrapped"`
+	Deleted              []Reference          `json:"deleted"`
+	UnwrappedThenDeleted []Reference          `json:"unwrappedThenDeleted"`
+	Wrapped              []Reference          `json:"wrapped"`
+	GasObject            GasObject            `json:"gasObject"`
+	EventsDigest         string               `json:"eventsDigest"`
+	Dependencies         []string             `json:"dependencies"`
 }
 
-type GasObject struct {
-	Owner     Owner     `json:"owner"`
-	Reference Reference `json:"reference"`
+func (e *Effects) CreatedObjectIDs() []string {
+	var objectIds []string
+	for _, item := range e.Created {
+		objectIds = append(objectIds, item.Reference.ObjectID)
+	}
+	return objectIds
 }
 
-type Effects struct {
-	MessageVersion string  `json:"messageVersion"`
-	Status         Status  `json:"status"`
-	ExecutedEpoch  string  `json:"executedEpoch"`
-	GasUsed        GasUsed `json:"gasUsed"`
-	//ModifiedAtVersions []ModifiedAtVersions `json:"modifiedAtVersions"`
-	TransactionDigest string `json:"transactionDigest"`
-	//Created            []Created            `json:"created"`
-	//Mutated            []Mutated            `json:"mutated"`
-	//GasObject    GasObject `json:"gasObject"`
-	//Dependencies []string  `json:"dependencies"`
+func (e *Effects) MutatedObjectIDs() []string {
+	var objectIds []string
+	for _, item := range e.Mutated {
+		objectIds = append(objectIds, item.Reference.ObjectID)
+	}
+	return objectIds
+}
+
+func (e *Effects) DeletedObjectIDs() []string {
+	var objectIds []string
+	for _, item := range e.Deleted {
+		objectIds = append(objectIds, item.ObjectID)
+	}
+	for _, item := range e.UnwrappedThenDeleted {
+		objectIds = append(objectIds, item.ObjectID)
+	}
+	return objectIds
+}
+
+func (e *Effects) SharedObjectVersion(objectId string) (int, bool) {
+	for _, item := range e.SharedObjects {
+		if NormalizeAddress(item.ObjectID) == NormalizeAddress(objectId) {
+			return item.Version, true
+		}
+	}
+	return 0, false
 }
 
 type ObjectChanges struct {
-	Type            string `json:"type"`
-	Sender          string `json:"sender"`
-	Owner           Owner  `json:"owner"`
-	ObjectType      string `json:"objectType"`
-	ObjectID        string `json:"objectId"`
-	Version         string `json:"version"`
-	PreviousVersion string `json:"previousVersion,omitempty"`
-	Digest          string `json:"digest"`
+	Type            ObjectChangeType `json:"type"`
+	Sender          string           `json:"sender"`
+	Owner           Owner            `json:"owner"`
+	Recipient       Owner            `json:"recipient"`
+	ObjectType      string           `json:"objectType"`
+	ObjectID        string           `json:"objectId"`
+	PackageID       string           `json:"packageId"`
+	Modules         []string         `json:"modules"`
+	Version         string           `json:"version"`
+	PreviousVersion string           `json:"previousVersion,omitempty"`
+	Digest          string           `json:"digest"`
 }
 
 type BalanceChanges struct {
@@ -0,0 +1,710 @@
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"golang.org/x/crypto/blake2b"
+	"strconv"
+)
+
+// ObjectRef is (object id, version, digest), the digest is base58 in go and 32 length-prefixed bytes in bcs
+type ObjectRef struct {
+	ObjectID Address
+	Version  uint64
+	Digest   string
+}
+
+func (or ObjectRef) MarshalBCS(e *bcs.Encoder) error {
+	err := or.ObjectID.MarshalBCS(e)
+	if err != nil {
+		return err
+	}
+	e.WriteU64(or.Version)
+	digest, err := Base58Decode(or.Digest)
+	if err != nil {
+		return err
+	}
+	if len(digest) != 32 {
+		return fmt.Errorf("invalid object digest: %v", or.Digest)
+	}
+	e.WriteBytes(digest)
+	return nil
+}
+
+func (or *ObjectRef) UnmarshalBCS(d *bcs.Decoder) error {
+	err := or.ObjectID.UnmarshalBCS(d)
+	if err != nil {
+		return err
+	}
+	or.Version, err = d.ReadU64()
+	if err != nil {
+		return err
+	}
+	digest, err := d.ReadBytes()
+	if err != nil {
+		return err
+	}
+	or.Digest = Base58Encode(digest)
+	return nil
+}
+
+// ObjectRef of a coin, e.g. to use it as an input or gas payment
+func (cd *CoinData) ObjectRef() (ObjectRef, error) {
+	version, err := strconv.ParseUint(cd.Version, 10, 64)
+	if err != nil {
+		return ObjectRef{}, fmt.Errorf("invalid coin version %v: %v", cd.Version, err)
+	}
+	return ObjectRef{ObjectID: Address(cd.CoinObjectID), Version: version, Digest: cd.Digest}, nil
+}
+
+func (o *Object) ObjectRef() (ObjectRef, error) {
+	version, err := strconv.ParseUint(o.Version, 10, 64)
+	if err != nil {
+		return ObjectRef{}, fmt.Errorf("invalid object version %v: %v", o.Version, err)
+	}
+	return ObjectRef{ObjectID: Address(o.ObjectID), Version: version, Digest: o.Digest}, nil
+}
+
+func (p Payment) ObjectRef() ObjectRef {
+	return ObjectRef{ObjectID: Address(p.ObjectID), Version: uint64(p.Version), Digest: p.Digest}
+}
+
+func NewPayment(ref ObjectRef) Payment {
+	return Payment{ObjectID: ref.ObjectID.String(), Version: int(ref.Version), Digest: ref.Digest}
+}
+
+func (p Payment) MarshalBCS(e *bcs.Encoder) error {
+	return p.ObjectRef().MarshalBCS(e)
+}
+
+func (p *Payment) UnmarshalBCS(d *bcs.Decoder) error {
+	var ref ObjectRef
+	err := ref.UnmarshalBCS(d)
+	if err != nil {
+		return err
+	}
+	*p = NewPayment(ref)
+	return nil
+}
+
+func (gd GasData) MarshalBCS(e *bcs.Encoder) error {
+	e.WriteUleb128(uint64(len(gd.Payment)))
+	for _, payment := range gd.Payment {
+		err := payment.MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+	}
+	err := Address(gd.Owner).MarshalBCS(e)
+	if err != nil {
+		return err
+	}
+	price, err := strconv.ParseUint(gd.Price, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid gas price %v: %v", gd.Price, err)
+	}
+	budget, err := strconv.ParseUint(gd.Budget, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid gas budget %v: %v", gd.Budget, err)
+	}
+	e.WriteU64(price)
+	e.WriteU64(budget)
+	return nil
+}
+
+func (gd *GasData) UnmarshalBCS(d *bcs.Decoder) error {
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	gd.Payment = make([]Payment, count)
+	for i := range gd.Payment {
+		err = gd.Payment[i].UnmarshalBCS(d)
+		if err != nil {
+			return err
+		}
+	}
+	var owner Address
+	err = owner.UnmarshalBCS(d)
+	if err != nil {
+		return err
+	}
+	price, err := d.ReadU64()
+	if err != nil {
+		return err
+	}
+	budget, err := d.ReadU64()
+	if err != nil {
+		return err
+	}
+	gd.Owner, gd.Price, gd.Budget = owner.String(), strconv.FormatUint(price, 10), strconv.FormatUint(budget, 10)
+	return nil
+}
+
+// SharedObjectRef is a shared object input, InitialSharedVersion comes from Owner.InitialSharedVersion
+type SharedObjectRef struct {
+	ObjectID             Address
+	InitialSharedVersion uint64
+	Mutable              bool
+}
+
+// ObjectArg is an object input, exactly one field is set
+type ObjectArg struct {
+	ImmOrOwned *ObjectRef
+	Shared     *SharedObjectRef
+	Receiving  *ObjectRef
+}
+
+func (oa ObjectArg) ObjectID() Address {
+	switch {
+	case oa.ImmOrOwned != nil:
+		return oa.ImmOrOwned.ObjectID
+	case oa.Shared != nil:
+		return oa.Shared.ObjectID
+	case oa.Receiving != nil:
+		return oa.Receiving.ObjectID
+	default:
+		return ""
+	}
+}
+
+func (oa ObjectArg) MarshalBCS(e *bcs.Encoder) error {
+	switch {
+	case oa.ImmOrOwned != nil:
+		e.WriteUleb128(0)
+		return oa.ImmOrOwned.MarshalBCS(e)
+	case oa.Shared != nil:
+		e.WriteUleb128(1)
+		err := oa.Shared.ObjectID.MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+		e.WriteU64(oa.Shared.InitialSharedVersion)
+		e.WriteBool(oa.Shared.Mutable)
+		return nil
+	case oa.Receiving != nil:
+		e.WriteUleb128(2)
+		return oa.Receiving.MarshalBCS(e)
+	default:
+		return fmt.Errorf("empty object arg")
+	}
+}
+
+func (oa *ObjectArg) UnmarshalBCS(d *bcs.Decoder) error {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	*oa = ObjectArg{}
+	switch tag {
+	case 0:
+		oa.ImmOrOwned = &ObjectRef{}
+		return oa.ImmOrOwned.UnmarshalBCS(d)
+	case 1:
+		oa.Shared = &SharedObjectRef{}
+		err = oa.Shared.ObjectID.UnmarshalBCS(d)
+		if err != nil {
+			return err
+		}
+		oa.Shared.InitialSharedVersion, err = d.ReadU64()
+		if err != nil {
+			return err
+		}
+		oa.Shared.Mutable, err = d.ReadBool()
+		return err
+	case 2:
+		oa.Receiving = &ObjectRef{}
+		return oa.Receiving.UnmarshalBCS(d)
+	default:
+		return fmt.Errorf("unknown object arg: %v", tag)
+	}
+}
+
+// CallArg is a programmable transaction input: bcs bytes of a pure value, or an object
+type CallArg struct {
+	Pure   []byte
+	Object *ObjectArg
+}
+
+// PureCallArg serializes value with bcs, e.g. uint64 for u64 and Address for address
+func PureCallArg(value interface{}) (CallArg, error) {
+	data, err := bcs.Marshal(value)
+	if err != nil {
+		return CallArg{}, err
+	}
+	return CallArg{Pure: data}, nil
+}
+
+func ObjectCallArg(arg ObjectArg) CallArg {
+	return CallArg{Object: &arg}
+}
+
+func (ca CallArg) MarshalBCS(e *bcs.Encoder) error {
+	if ca.Object != nil {
+		e.WriteUleb128(1)
+		return ca.Object.MarshalBCS(e)
+	}
+	e.WriteUleb128(0)
+	e.WriteBytes(ca.Pure)
+	return nil
+}
+
+func (ca *CallArg) UnmarshalBCS(d *bcs.Decoder) error {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	*ca = CallArg{}
+	switch tag {
+	case 0:
+		ca.Pure, err = d.ReadBytes()
+		return err
+	case 1:
+		ca.Object = &ObjectArg{}
+		return ca.Object.UnmarshalBCS(d)
+	default:
+		return fmt.Errorf("unknown call arg: %v", tag)
+	}
+}
+
+type ProgrammableTransaction struct {
+	Inputs   []CallArg
+	Commands []Command
+}
+
+// InputObjectIDs are the ids of the object inputs, which can not also pay gas
+func (pt *ProgrammableTransaction) InputObjectIDs() []string {
+	var objectIds []string
+	for _, input := range pt.Inputs {
+		if input.Object != nil {
+			objectIds = append(objectIds, input.Object.ObjectID().String())
+		}
+	}
+	return objectIds
+}
+
+type TransactionExpiration struct {
+	// Epoch is the last epoch the transaction can execute in, nil for no expiration
+	Epoch *uint64
+}
+
+func (te TransactionExpiration) MarshalBCS(e *bcs.Encoder) error {
+	if te.Epoch == nil {
+		e.WriteUleb128(0)
+		return nil
+	}
+	e.WriteUleb128(1)
+	e.WriteU64(*te.Epoch)
+	return nil
+}
+
+func (te *TransactionExpiration) UnmarshalBCS(d *bcs.Decoder) error {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	switch tag {
+	case 0:
+		te.Epoch = nil
+		return nil
+	case 1:
+		epoch, err := d.ReadU64()
+		if err != nil {
+			return err
+		}
+		te.Epoch = &epoch
+		return nil
+	default:
+		return fmt.Errorf("unsupported transaction expiration: %v", tag)
+	}
+}
+
+// TransactionExpiredError rejects a transaction whose expiration epoch has passed
+type TransactionExpiredError struct {
+	ExpirationEpoch uint64
+	CurrentEpoch    uint64
+}
+
+func (e *TransactionExpiredError) Error() string {
+	return fmt.Sprintf("transaction expired at epoch %v, current epoch is %v", e.ExpirationEpoch, e.CurrentEpoch)
+}
+
+// UnsupportedTransactionKindError rejects tx bytes that are not a programmable transaction
+type UnsupportedTransactionKindError struct {
+	Kind uint64
+}
+
+func (e *UnsupportedTransactionKindError) Error() string {
+	return fmt.Sprintf("unsupported transaction kind: %v", e.Kind)
+}
+
+// TransactionData is the bcs TransactionData::V1 behind txBytes, only programmable transactions are supported
+type TransactionData struct {
+	Kind       ProgrammableTransaction
+	Sender     Address
+	GasData    GasData
+	Expiration TransactionExpiration
+}
+
+func NewTransactionData(sender string, kind ProgrammableTransaction, gasData GasData) *TransactionData {
+	return &TransactionData{Kind: kind, Sender: Address(sender), GasData: gasData}
+}
+
+// ParseTransactionData decodes base64 txBytes, e.g. from the unsafe_* builders
+func ParseTransactionData(txBytes string) (*TransactionData, error) {
+	data, err := base64.StdEncoding.DecodeString(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	result := &TransactionData{}
+	err = bcs.Unmarshal(data, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (td TransactionData) MarshalBCS(e *bcs.Encoder) error {
+	// TransactionData::V1, TransactionKind::ProgrammableTransaction
+	e.WriteUleb128(0)
+	e.WriteUleb128(0)
+	err := e.Encode(&td.Kind)
+	if err != nil {
+		return err
+	}
+	err = td.Sender.MarshalBCS(e)
+	if err != nil {
+		return err
+	}
+	err = td.GasData.MarshalBCS(e)
+	if err != nil {
+		return err
+	}
+	return td.Expiration.MarshalBCS(e)
+}
+
+func (td *TransactionData) UnmarshalBCS(d *bcs.Decoder) error {
+	version, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	if version != 0 {
+		return fmt.Errorf("unsupported transaction data version: %v", version)
+	}
+	kind, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	if kind != 0 {
+		return &UnsupportedTransactionKindError{Kind: kind}
+	}
+	err = d.Decode(&td.Kind)
+	if err != nil {
+		return err
+	}
+	err = td.Sender.UnmarshalBCS(d)
+	if err != nil {
+		return err
+	}
+	err = td.GasData.UnmarshalBCS(d)
+	if err != nil {
+		return err
+	}
+	return td.Expiration.UnmarshalBCS(d)
+}
+
+// SetExpirationEpoch makes the transaction executable up to and including epoch
+func (td *TransactionData) SetExpirationEpoch(epoch uint64) {
+	td.Expiration.Epoch = &epoch
+}
+
+// CheckExpiration returns *TransactionExpiredError when currentEpoch is past the expiration epoch
+func (td *TransactionData) CheckExpiration(currentEpoch uint64) error {
+	if td.Expiration.Epoch != nil && currentEpoch > *td.Expiration.Epoch {
+		return &TransactionExpiredError{ExpirationEpoch: *td.Expiration.Epoch, CurrentEpoch: currentEpoch}
+	}
+	return nil
+}
+
+// Signers are the addresses that must sign: the sender, and the gas owner when it sponsors the gas
+func (td *TransactionData) Signers() []string {
+	sender := NormalizeAddress(td.Sender.String())
+	signers := []string{sender}
+	if owner := NormalizeAddress(td.GasData.Owner); owner != sender {
+		signers = append(signers, owner)
+	}
+	return signers
+}
+
+func (td *TransactionData) Bytes() ([]byte, error) {
+	return bcs.Marshal(td)
+}
+
+// TxBytes is the base64 form expected by sui_executeTransactionBlock and the signing helpers
+func (td *TransactionData) TxBytes() (string, error) {
+	data, err := td.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Digest is the base58 transaction digest the node will report
+func (td *TransactionData) Digest() (string, error) {
+	data, err := td.Bytes()
+	if err != nil {
+		return "", err
+	}
+	digest := blake2b.Sum256(append([]byte("TransactionData::"), data...))
+	return Base58Encode(digest[:]), nil
+}
+
+// UnsignedTx wraps the bytes like the unsafe_* builders return them
+func (td *TransactionData) UnsignedTx() (*UnsignedTx, error) {
+	txBytes, err := td.TxBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &UnsignedTx{TxBytes: txBytes}, nil
+}
+
+func (a Argument) MarshalBCS(e *bcs.Encoder) error {
+	switch a.Kind {
+	case GasCoinArgumentKind:
+		e.WriteUleb128(0)
+	case InputArgumentKind:
+		e.WriteUleb128(1)
+		e.WriteU16(a.Index)
+	case ResultArgumentKind:
+		e.WriteUleb128(2)
+		e.WriteU16(a.Index)
+	case NestedResultArgumentKind:
+		e.WriteUleb128(3)
+		e.WriteU16(a.Index)
+		e.WriteU16(a.ResultIndex)
+	default:
+		return fmt.Errorf("unknown argument kind: %v", a.Kind)
+	}
+	return nil
+}
+
+func (a *Argument) UnmarshalBCS(d *bcs.Decoder) error {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	switch tag {
+	case 0:
+		*a = GasCoinArgument()
+		return nil
+	case 1, 2:
+		index, err := d.ReadU16()
+		if err != nil {
+			return err
+		}
+		if tag == 1 {
+			*a = InputArgument(index)
+		} else {
+			*a = ResultArgument(index)
+		}
+		return nil
+	case 3:
+		index, err := d.ReadU16()
+		if err != nil {
+			return err
+		}
+		resultIndex, err := d.ReadU16()
+		if err != nil {
+			return err
+		}
+		*a = NestedResultArgument(index, resultIndex)
+		return nil
+	default:
+		return fmt.Errorf("unknown argument: %v", tag)
+	}
+}
+
+func writeObjectIds(e *bcs.Encoder, ids []string) error {
+	e.WriteUleb128(uint64(len(ids)))
+	for _, id := range ids {
+		err := Address(id).MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readObjectIds(d *bcs.Decoder) ([]string, error) {
+	var ids []Address
+	err := d.Decode(&ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result, nil
+}
+
+func (c Command) MarshalBCS(e *bcs.Encoder) error {
+	switch c.Kind() {
+	case MoveCallCommandKind:
+		e.WriteUleb128(0)
+		err := Address(c.MoveCall.Package).MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+		e.WriteString(c.MoveCall.Module)
+		e.WriteString(c.MoveCall.Function)
+		e.WriteUleb128(uint64(len(c.MoveCall.TypeArguments)))
+		for _, typeArgument := range c.MoveCall.TypeArguments {
+			err = TypeTag(typeArgument).MarshalBCS(e)
+			if err != nil {
+				return err
+			}
+		}
+		return e.Encode(c.MoveCall.Arguments)
+	case TransferObjectsCommandKind:
+		e.WriteUleb128(1)
+		err := e.Encode(c.TransferObjects.Objects)
+		if err != nil {
+			return err
+		}
+		return c.TransferObjects.Address.MarshalBCS(e)
+	case SplitCoinsCommandKind:
+		e.WriteUleb128(2)
+		err := c.SplitCoins.Coin.MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+		return e.Encode(c.SplitCoins.Amounts)
+	case MergeCoinsCommandKind:
+		e.WriteUleb128(3)
+		err := c.MergeCoins.Destination.MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+		return e.Encode(c.MergeCoins.Sources)
+	case PublishCommandKind:
+		e.WriteUleb128(4)
+		err := e.Encode(c.Publish.Modules)
+		if err != nil {
+			return err
+		}
+		return writeObjectIds(e, c.Publish.Dependencies)
+	case MakeMoveVecCommandKind:
+		e.WriteUleb128(5)
+		e.WriteBool(c.MakeMoveVec.Type != nil)
+		if c.MakeMoveVec.Type != nil {
+			err := TypeTag(*c.MakeMoveVec.Type).MarshalBCS(e)
+			if err != nil {
+				return err
+			}
+		}
+		return e.Encode(c.MakeMoveVec.Elements)
+	case UpgradeCommandKind:
+		e.WriteUleb128(6)
+		err := e.Encode(c.Upgrade.Modules)
+		if err != nil {
+			return err
+		}
+		err = writeObjectIds(e, c.Upgrade.Dependencies)
+		if err != nil {
+			return err
+		}
+		err = Address(c.Upgrade.Package).MarshalBCS(e)
+		if err != nil {
+			return err
+		}
+		return c.Upgrade.Ticket.MarshalBCS(e)
+	default:
+		return fmt.Errorf("empty command")
+	}
+}
+
+func (c *Command) UnmarshalBCS(d *bcs.Decoder) error {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return err
+	}
+	*c = Command{}
+	switch tag {
+	case 0:
+		var moveCall struct {
+			Package       Address
+			Module        string
+			Function      string
+			TypeArguments []TypeTag
+			Arguments     []Argument
+		}
+		err = d.Decode(&moveCall)
+		if err != nil {
+			return err
+		}
+		c.MoveCall = &MoveCallCommand{
+			Package:   moveCall.Package.String(),
+			Module:    moveCall.Module,
+			Function:  moveCall.Function,
+			Arguments: moveCall.Arguments,
+		}
+		for _, typeArgument := range moveCall.TypeArguments {
+			c.MoveCall.TypeArguments = append(c.MoveCall.TypeArguments, typeArgument.String())
+		}
+		return nil
+	case 1:
+		c.TransferObjects = &TransferObjectsCommand{}
+		return d.Decode(c.TransferObjects)
+	case 2:
+		c.SplitCoins = &SplitCoinsCommand{}
+		return d.Decode(c.SplitCoins)
+	case 3:
+		c.MergeCoins = &MergeCoinsCommand{}
+		return d.Decode(c.MergeCoins)
+	case 4:
+		c.Publish = &PublishCommand{}
+		err = d.Decode(&c.Publish.Modules)
+		if err != nil {
+			return err
+		}
+		c.Publish.Dependencies, err = readObjectIds(d)
+		return err
+	case 5:
+		c.MakeMoveVec = &MakeMoveVecCommand{}
+		hasType, err := d.ReadBool()
+		if err != nil {
+			return err
+		}
+		if hasType {
+			var moveType TypeTag
+			err = moveType.UnmarshalBCS(d)
+			if err != nil {
+				return err
+			}
+			typeName := moveType.String()
+			c.MakeMoveVec.Type = &typeName
+		}
+		return d.Decode(&c.MakeMoveVec.Elements)
+	case 6:
+		c.Upgrade = &UpgradeCommand{}
+		err = d.Decode(&c.Upgrade.Modules)
+		if err != nil {
+			return err
+		}
+		c.Upgrade.Dependencies, err = readObjectIds(d)
+		if err != nil {
+			return err
+		}
+		var packageId Address
+		err = packageId.UnmarshalBCS(d)
+		if err != nil {
+			return err
+		}
+		c.Upgrade.Package = packageId.String()
+		return c.Upgrade.Ticket.UnmarshalBCS(d)
+	default:
+		return fmt.Errorf("unknown command: %v", tag)
+	}
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"reflect"
+	"testing"
+)
+
+// the expected bytes and digest are assembled by hand from the TransactionData::V1 layout,
+// not by this package, so a wrong field order or enum tag shows up here
+const (
+	testTxBytes  = "AAADAAjoAwAAAAAAAAAgz5kREREREREREREREREREREREREREREREREREREREREBANm3MFREjYTNKCtQGzG1CRZMSsPmVo2TI+dAvhmjX6/yBwAAAAAAAAAgY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2MDAgABAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACA3BheQRqb2luAQcAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAgNzdWkDU1VJAAIBAgADAAAAAAEBAQIAAQEAopCd01Wur/1SCEejXaHvUpxM69NIu01p8A8yMVEYEG4ByDlezD0xhFAytUHLC7qtgssyQ0GHs+tGVGZHPBCijYwqAAAAAAAAACBjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY2NjY6KQndNVrq/9UghHo12h71KcTOvTSLtNafAPMjFRGBBu6AMAAAAAAABAS0wAAAAAAAA="
+	testTxDigest = "3sPzHrdMYVms2kh4fuXyA51YBQMAeDEetxBafTuj5Qj9"
+)
+
+func newTestTransactionData(t *testing.T) *TransactionData {
+	sender := "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e"
+	builder := NewProgrammableTransactionBuilder()
+	amount, err := builder.Pure(uint64(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient, err := builder.Pure(Address("0xcf99111111111111111111111111111111111111111111111111111111111111"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	coin := builder.OwnedObject(ObjectRef{
+		ObjectID: "0xd9b73054448d84cd282b501b31b509164c4ac3e6568d9323e740be19a35faff2",
+		Version:  7,
+		Digest:   "7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz",
+	})
+	builder.SplitCoins(GasCoinArgument(), []Argument{amount})
+	builder.MoveCall("0x2", "pay", "join", []string{"0x2::sui::SUI"}, []Argument{coin, NestedResultArgument(0, 0)})
+	builder.TransferObjects([]Argument{coin}, recipient)
+	gasData := GasData{
+		Payment: []Payment{NewPayment(ObjectRef{
+			ObjectID: "0xc8395ecc3d31845032b541cb0bbaad82cb32434187b3eb465466473c10a28d8c",
+			Version:  42,
+			Digest:   "7gyGAp71YXQRoxmFBaHxofQXAipvgHyBKPyxmdSJxyvz",
+		})},
+		Owner:  sender,
+		Price:  "1000",
+		Budget: "5000000",
+	}
+	return NewTransactionData(sender, builder.Finish(), gasData)
+}
+
+func TestTransactionData_KnownBytes(t *testing.T) {
+	txData := newTestTransactionData(t)
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txBytes != testTxBytes {
+		t.Fatalf("tx bytes %v, expect %v", txBytes, testTxBytes)
+	}
+	digest, err := txData.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != testTxDigest {
+		t.Fatalf("digest %v, expect %v", digest, testTxDigest)
+	}
+
+	parsed, err := ParseTransactionData(testTxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := parsed.TxBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != testTxBytes || !reflect.DeepEqual(parsed.Kind.Commands[0], txData.Kind.Commands[0]) {
+		t.Fatalf("parsed tx bytes do not roundtrip: %v", again)
+	}
+	if NormalizeType(parsed.Kind.Commands[1].MoveCall.TypeArguments[0]) != NormalizeType("0x2::sui::SUI") {
+		t.Fatalf("unexpected type argument: %v", parsed.Kind.Commands[1].MoveCall.TypeArguments)
+	}
+}
+
+func TestTransactionData_Roundtrip(t *testing.T) {
+	builder := NewProgrammableTransactionBuilder()
+	coin := builder.OwnedObject(ObjectRef{ObjectID: "0x5", Version: 7, Digest: "4kLz3vWhrKv3h1yiF3dVKLcWJZwACkf9F1kx8qWdjxqf"})
+	amount, err := builder.Pure(uint64(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	split := builder.SplitCoins(coin, []Argument{amount})
+	builder.MergeCoins(GasCoinArgument(), []Argument{NestedResultArgument(split.Index, 0)})
+	builder.MoveCall("0x2", "pay", "join_vec", []string{"0x2::sui::SUI", "vector<u8>"}, []Argument{GasCoinArgument(), coin})
+	txData := newTestTransactionData(t)
+	txData.Kind = builder.Finish()
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseTransactionData(txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reencoded, err := parsed.TxBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reencoded != txBytes {
+		t.Fatalf("transaction data roundtrip not match: %v %v", txBytes, reencoded)
+	}
+	moveCall := parsed.Kind.Commands[2].MoveCall
+	if moveCall == nil || moveCall.TypeArguments[1] != "vector<u8>" || parsed.GasData.Budget != "5000000" {
+		t.Fatalf("unexpected transaction data: %+v", parsed)
+	}
+}
+
+func TestTransactionData_Expiration(t *testing.T) {
+	txData := newTestTransactionData(t)
+	txData.SetExpirationEpoch(100)
+	txBytes, err := txData.TxBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseTransactionData(txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Expiration.Epoch == nil || *parsed.Expiration.Epoch != 100 {
+		t.Fatalf("unexpected expiration: %v", parsed.Expiration.Epoch)
+	}
+	if err := parsed.CheckExpiration(100); err != nil {
+		t.Fatalf("transaction expired early: %v", err)
+	}
+	if _, ok := parsed.CheckExpiration(101).(*TransactionExpiredError); !ok {
+		t.Fatalf("expired transaction accepted")
+	}
+
+	_, err = ParseTransactionData(base64.StdEncoding.EncodeToString([]byte{0, 1}))
+	if _, ok := err.(*UnsupportedTransactionKindError); !ok {
+		t.Fatalf("expect unsupported kind error: %v", err)
+	}
+}
+
+func TestTypeTag_KnownBytes(t *testing.T) {
+	expect := "07" + "0000000000000000000000000000000000000000000000000000000000000002" + "04636f696e" + "04436f696e" + "01" +
+		"07" + "0000000000000000000000000000000000000000000000000000000000000002" + "03737569" + "03535549" + "00"
+	for _, moveType := range []string{"0x2::coin::Coin<0x2::sui::SUI>", " 0x0002::coin::Coin< 0x2::sui::SUI > "} {
+		data, err := bcs.Marshal(TypeTag(moveType))
+		if err != nil {
+			t.Fatalf("%v: %v", moveType, err)
+		}
+		if hex.EncodeToString(data) != expect {
+			t.Fatalf("%v: got %x, expect %v", moveType, data, expect)
+		}
+	}
+	data, _ := hex.DecodeString(expect)
+	var tag TypeTag
+	err := bcs.Unmarshal(data, &tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if NormalizeType(tag.String()) != NormalizeType("0x2::coin::Coin<0x2::sui::SUI>") {
+		t.Fatalf("unexpected type tag: %v", tag)
+	}
+
+	for _, moveType := range []string{"0x2::coin", "0x2::coin::Coin<0x2::sui::SUI", "0xzz::coin::Coin"} {
+		_, err := bcs.Marshal(TypeTag(moveType))
+		if err == nil {
+			t.Fatalf("%v: expect error", moveType)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type TransactionKindType string
+
+const (
+	ProgrammableTransactionKind  TransactionKindType = "ProgrammableTransaction"
+	ChangeEpochKind              TransactionKindType = "ChangeEpoch"
+	GenesisKind                  TransactionKindType = "Genesis"
+	ConsensusCommitPrologueKind  TransactionKindType = "ConsensusCommitPrologue"
+	AuthenticatorStateUpdateKind TransactionKindType = "AuthenticatorStateUpdate"
+	RandomnessStateUpdateKind    TransactionKindType = "RandomnessStateUpdate"
+	EndOfEpochTransactionKind    TransactionKindType = "EndOfEpochTransaction"
+)
+
+func (tkt TransactionKindType) String() string {
+	return string(tkt)
+}
+
+// DataTransaction is the transaction kind, Inputs and Transactions are set for ProgrammableTransaction,
+// otherwise the pointer matching Kind is set. Kinds unknown to this sdk keep their json in Raw.
+type DataTransaction struct {
+	Kind                     TransactionKindType
+	Inputs                   []TransactionInput
+	Transactions             []Command
+	ChangeEpoch              *ChangeEpoch
+	Genesis                  *Genesis
+	ConsensusCommitPrologue  *ConsensusCommitPrologue
+	AuthenticatorStateUpdate *AuthenticatorStateUpdate
+	RandomnessStateUpdate    *RandomnessStateUpdate
+	EndOfEpochTransaction    *EndOfEpochTransaction
+	Raw                      json.RawMessage
+}
+
+type programmableTransaction struct {
+	Inputs       []TransactionInput `json:"inputs"`
+	Transactions []Command          `json:"transactions"`
+}
+
+func (dt *DataTransaction) UnmarshalJSON(data []byte) error {
+	*dt = DataTransaction{}
+	if string(data) == "null" {
+		return nil
+	}
+	var kind struct {
+		Kind string `json:"kind"`
+	}
+	err := json.Unmarshal(data, &kind)
+	if err != nil {
+		return err
+	}
+	dt.Kind = TransactionKindType(kind.Kind)
+	switch {
+	case dt.Kind == ProgrammableTransactionKind:
+		tx := programmableTransaction{}
+		err = json.Unmarshal(data, &tx)
+		dt.Inputs, dt.Transactions = tx.Inputs, tx.Transactions
+	case dt.Kind == ChangeEpochKind:
+		dt.ChangeEpoch = &ChangeEpoch{}
+		err = json.Unmarshal(data, dt.ChangeEpoch)
+	case dt.Kind == GenesisKind:
+		dt.Genesis = &Genesis{}
+		err = json.Unmarshal(data, dt.Genesis)
+	case strings.HasPrefix(kind.Kind, ConsensusCommitPrologueKind.String()):
+		// ConsensusCommitPrologue, ConsensusCommitPrologueV2, V3 ...
+		dt.Kind = ConsensusCommitPrologueKind
+		dt.ConsensusCommitPrologue = &ConsensusCommitPrologue{Version: kind.Kind}
+		err = json.Unmarshal(data, dt.ConsensusCommitPrologue)
+	case dt.Kind == AuthenticatorStateUpdateKind:
+		dt.AuthenticatorStateUpdate = &AuthenticatorStateUpdate{}
+		err = json.Unmarshal(data, dt.AuthenticatorStateUpdate)
+	case dt.Kind == RandomnessStateUpdateKind:
+		dt.RandomnessStateUpdate = &RandomnessStateUpdate{}
+		err = json.Unmarshal(data, dt.RandomnessStateUpdate)
+	case dt.Kind == EndOfEpochTransactionKind:
+		dt.EndOfEpochTransaction = &EndOfEpochTransaction{}
+		err = json.Unmarshal(data, dt.EndOfEpochTransaction)
+	default:
+		dt.Raw = append(json.RawMessage{}, data...)
+	}
+	return err
+}
+
+func (dt DataTransaction) MarshalJSON() ([]byte, error) {
+	var value interface{}
+	switch dt.Kind {
+	case "":
+		return []byte("null"), nil
+	case ProgrammableTransactionKind:
+		value = programmableTransaction{Inputs: dt.Inputs, Transactions: dt.Transactions}
+	case ChangeEpochKind:
+		value = dt.ChangeEpoch
+	case GenesisKind:
+		value = dt.Genesis
+	case ConsensusCommitPrologueKind:
+		value = dt.ConsensusCommitPrologue
+	case AuthenticatorStateUpdateKind:
+		value = dt.AuthenticatorStateUpdate
+	case RandomnessStateUpdateKind:
+		value = dt.RandomnessStateUpdate
+	case EndOfEpochTransactionKind:
+		value = dt.EndOfEpochTransaction
+	default:
+		if dt.Raw != nil {
+			return dt.Raw, nil
+		}
+		return nil, fmt.Errorf("unknown transaction kind: %v", dt.Kind)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	kind := dt.Kind.String()
+	if dt.ConsensusCommitPrologue != nil && dt.ConsensusCommitPrologue.Version != "" {
+		kind = dt.ConsensusCommitPrologue.Version
+	}
+	fields["kind"], _ = json.Marshal(kind)
+	return json.Marshal(fields)
+}
+
+func (dt *DataTransaction) IsProgrammable() bool {
+	return dt.Kind == ProgrammableTransactionKind
+}
+
+func (dt *DataTransaction) MoveCalls() []MoveCallCommand {
+	var result []MoveCallCommand
+	for _, command := range dt.Transactions {
+		if command.MoveCall != nil {
+			result = append(result, *command.MoveCall)
+		}
+	}
+	return result
+}
+
+type ChangeEpoch struct {
+	Epoch                 string `json:"epoch"`
+	StorageCharge         string `json:"storage_charge"`
+	ComputationCharge     string `json:"computation_charge"`
+	StorageRebate         string `json:"storage_rebate"`
+	EpochStartTimestampMs string `json:"epoch_start_timestamp_ms"`
+}
+
+type Genesis struct {
+	Objects []string `json:"objects"`
+}
+
+type ConsensusCommitPrologue struct {
+	// Version is the kind reported by the node: ConsensusCommitPrologue, ConsensusCommitPrologueV2 ...
+	Version                               string          `json:"-"`
+	Epoch                                 string          `json:"epoch"`
+	Round                                 string          `json:"round"`
+	SubDagIndex                           string          `json:"sub_dag_index,omitempty"`
+	CommitTimestampMs                     string          `json:"commit_timestamp_ms"`
+	ConsensusCommitDigest                 string          `json:"consensus_commit_digest,omitempty"`
+	ConsensusDeterminedVersionAssignments json.RawMessage `json:"consensus_determined_version_assignments,omitempty"`
+}
+
+type JwkId struct {
+	Iss string `json:"iss"`
+	Kid string `json:"kid"`
+}
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	E   string `json:"e"`
+	N   string `json:"n"`
+	Alg string `json:"alg"`
+}
+
+type ActiveJwk struct {
+	JwkId JwkId  `json:"jwk_id"`
+	Jwk   Jwk    `json:"jwk"`
+	Epoch string `json:"epoch"`
+}
+
+type AuthenticatorStateUpdate struct {
+	Epoch         string      `json:"epoch"`
+	Round         string      `json:"round"`
+	NewActiveJwks []ActiveJwk `json:"new_active_jwks"`
+}
+
+type RandomnessStateUpdate struct {
+	Epoch           string `json:"epoch"`
+	RandomnessRound string `json:"randomness_round"`
+	RandomBytes     []int  `json:"random_bytes"`
+}
+
+type EndOfEpochTransaction struct {
+	Transactions []json.RawMessage `json:"transactions"`
+}
+
+const (
+	PureInputType   = "pure"
+	ObjectInputType = "object"
+
+	ImmOrOwnedObjectInput = "immOrOwnedObject"
+	SharedObjectInput     = "sharedObject"
+	ReceivingObjectInput  = "receiving"
+)
+
+type TransactionInput struct {
+	Type                 string          `json:"type"`
+	ValueType            string          `json:"valueType,omitempty"`
+	Value                json.RawMessage `json:"value,omitempty"`
+	ObjectType           string          `json:"objectType,omitempty"`
+	ObjectID             string          `json:"objectId,omitempty"`
+	Version              string          `json:"version,omitempty"`
+	Digest               string          `json:"digest,omitempty"`
+	InitialSharedVersion string          `json:"initialSharedVersion,omitempty"`
+	Mutable              bool            `json:"mutable,omitempty"`
+}
+
+func (ti *TransactionInput) IsPure() bool {
+	return ti.Type == PureInputType
+}
+
+func (ti *TransactionInput) IsObject() bool {
+	return ti.Type == ObjectInputType
+}
+
+// PureValue decodes the pure value, e.g. into a string for u64 and address inputs
+func (ti *TransactionInput) PureValue(value interface{}) error {
+	if !ti.IsPure() {
+		return fmt.Errorf("input is not pure: %v", ti.Type)
+	}
+	return json.Unmarshal(ti.Value, value)
+}
+
+type ArgumentKind string
+
+const (
+	GasCoinArgumentKind      ArgumentKind = "GasCoin"
+	InputArgumentKind        ArgumentKind = "Input"
+	ResultArgumentKind       ArgumentKind = "Result"
+	NestedResultArgumentKind ArgumentKind = "NestedResult"
+)
+
+// Argument refers to the gas coin, an input, or the result of a previous command.
+// Arguments unknown to this sdk keep their json in Raw.
+type Argument struct {
+	Kind        ArgumentKind
+	Index       uint16
+	ResultIndex uint16
+	Raw         json.RawMessage
+}
+
+func GasCoinArgument() Argument {
+	return Argument{Kind: GasCoinArgumentKind}
+}
+
+func InputArgument(index uint16) Argument {
+	return Argument{Kind: InputArgumentKind, Index: index}
+}
+
+func ResultArgument(index uint16) Argument {
+	return Argument{Kind: ResultArgumentKind, Index: index}
+}
+
+func NestedResultArgument(index, resultIndex uint16) Argument {
+	return Argument{Kind: NestedResultArgumentKind, Index: index, ResultIndex: resultIndex}
+}
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		*a = GasCoinArgument()
+		if ArgumentKind(kind) != GasCoinArgumentKind {
+			*a = Argument{Kind: ArgumentKind(kind), Raw: append(json.RawMessage{}, data...)}
+		}
+		return nil
+	}
+	var variants map[ArgumentKind]json.RawMessage
+	err := json.Unmarshal(data, &variants)
+	if err != nil {
+		return err
+	}
+	if len(variants) != 1 {
+		return fmt.Errorf("unknown argument: %v", string(data))
+	}
+	for kind, value := range variants {
+		switch kind {
+		case InputArgumentKind, ResultArgumentKind:
+			*a = Argument{Kind: kind}
+			err = json.Unmarshal(value, &a.Index)
+		case NestedResultArgumentKind:
+			var indexes []uint16
+			err = json.Unmarshal(value, &indexes)
+			if err == nil && len(indexes) != 2 {
+				err = fmt.Errorf("invalid nested result: %v", string(value))
+			}
+			if err == nil {
+				*a = NestedResultArgument(indexes[0], indexes[1])
+			}
+		default:
+			*a = Argument{Kind: kind, Raw: append(json.RawMessage{}, data...)}
+		}
+	}
+	return err
+}
+
+func (a Argument) MarshalJSON() ([]byte, error) {
+	switch a.Kind {
+	case GasCoinArgumentKind:
+		return json.Marshal(a.Kind)
+	case InputArgumentKind, ResultArgumentKind:
+		return json.Marshal(map[ArgumentKind]uint16{a.Kind: a.Index})
+	case NestedResultArgumentKind:
+		return json.Marshal(map[ArgumentKind][]uint16{a.Kind: {a.Index, a.ResultIndex}})
+	default:
+		if a.Raw != nil {
+			return a.Raw, nil
+		}
+		return nil, fmt.Errorf("unknown argument kind: %v", a.Kind)
+	}
+}
+
+func (a Argument) String() string {
+	switch a.Kind {
+	case GasCoinArgumentKind:
+		return a.Kind.String()
+	case NestedResultArgumentKind:
+		return fmt.Sprintf("%v(%v,%v)", a.Kind, a.Index, a.ResultIndex)
+	default:
+		return fmt.Sprintf("%v(%v)", a.Kind, a.Index)
+	}
+}
+
+func (ak ArgumentKind) String() string {
+	return string(ak)
+}
+
+type CommandKind string
+
+const (
+	MoveCallCommandKind        CommandKind = "MoveCall"
+	TransferObjectsCommandKind CommandKind = "TransferObjects"
+	SplitCoinsCommandKind      CommandKind = "SplitCoins"
+	MergeCoinsCommandKind      CommandKind = "MergeCoins"
+	PublishCommandKind         CommandKind = "Publish"
+	UpgradeCommandKind         CommandKind = "Upgrade"
+	MakeMoveVecCommandKind     CommandKind = "MakeMoveVec"
+)
+
+func (ck CommandKind) String() string {
+	return string(ck)
+}
+
+// Command is one command of a programmable transaction, exactly one field is set
+type Command struct {
+	MoveCall        *MoveCallCommand        `json:"MoveCall,omitempty"`
+	TransferObjects *TransferObjectsCommand `json:"TransferObjects,omitempty"`
+	SplitCoins      *SplitCoinsCommand      `json:"SplitCoins,omitempty"`
+	MergeCoins      *MergeCoinsCommand      `json:"MergeCoins,omitempty"`
+	Publish         *PublishCommand         `json:"Publish,omitempty"`
+	Upgrade         *UpgradeCommand         `json:"Upgrade,omitempty"`
+	MakeMoveVec     *MakeMoveVecCommand     `json:"MakeMoveVec,omitempty"`
+}
+
+func (c *Command) Kind() CommandKind {
+	switch {
+	case c.MoveCall != nil:
+		return MoveCallCommandKind
+	case c.TransferObjects != nil:
+		return TransferObjectsCommandKind
+	case c.SplitCoins != nil:
+		return SplitCoinsCommandKind
+	case c.MergeCoins != nil:
+		return MergeCoinsCommandKind
+	case c.Publish != nil:
+		return PublishCommandKind
+	case c.Upgrade != nil:
+		return UpgradeCommandKind
+	case c.MakeMoveVec != nil:
+		return MakeMoveVecCommandKind
+	default:
+		return ""
+	}
+}
+
+type MoveCallCommand struct {
+	Package       string     `json:"package"`
+	Module        string     `json:"module"`
+	Function      string     `json:"function"`
+	TypeArguments []string   `json:"type_arguments,omitempty"`
+	Arguments     []Argument `json:"arguments,omitempty"`
+}
+
+func (mc *MoveCallCommand) Target() string {
+	return fmt.Sprintf("%v::%v::%v", mc.Package, mc.Module, mc.Function)
+}
+
+// TransferObjectsCommand is encoded as [objects, address]
+type TransferObjectsCommand struct {
+	Objects []Argument
+	Address Argument
+}
+
+func (tc *TransferObjectsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &tc.Objects, &tc.Address)
+}
+
+func (tc TransferObjectsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{tc.Objects, tc.Address})
+}
+
+// SplitCoinsCommand is encoded as [coin, amounts]
+type SplitCoinsCommand struct {
+	Coin    Argument
+	Amounts []Argument
+}
+
+func (sc *SplitCoinsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &sc.Coin, &sc.Amounts)
+}
+
+func (sc SplitCoinsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{sc.Coin, sc.Amounts})
+}
+
+// MergeCoinsCommand is encoded as [destination, sources]
+type MergeCoinsCommand struct {
+	Destination Argument
+	Sources     []Argument
+}
+
+func (mc *MergeCoinsCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &mc.Destination, &mc.Sources)
+}
+
+func (mc MergeCoinsCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{mc.Destination, mc.Sources})
+}
+
+// PublishCommand is encoded as the dependency list, module bytes are not returned by the node
+type PublishCommand struct {
+	Modules      [][]byte
+	Dependencies []string
+}
+
+func (pc *PublishCommand) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &pc.Dependencies)
+}
+
+func (pc PublishCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pc.Dependencies)
+}
+
+// UpgradeCommand is encoded as [dependencies, package, ticket]
+type UpgradeCommand struct {
+	Modules      [][]byte
+	Dependencies []string
+	Package      string
+	Ticket       Argument
+}
+
+func (uc *UpgradeCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &uc.Dependencies, &uc.Package, &uc.Ticket)
+}
+
+func (uc UpgradeCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{uc.Dependencies, uc.Package, uc.Ticket})
+}
+
+// MakeMoveVecCommand is encoded as [type or null, elements]
+type MakeMoveVecCommand struct {
+	Type     *string
+	Elements []Argument
+}
+
+func (mv *MakeMoveVecCommand) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &mv.Type, &mv.Elements)
+}
+
+func (mv MakeMoveVecCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{mv.Type, mv.Elements})
+}
+
+func unmarshalTuple(data []byte, values ...interface{}) error {
+	var items []json.RawMessage
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	if len(items) != len(values) {
+		return fmt.Errorf("expect %v items, got %v: %v", len(values), len(items), string(data))
+	}
+	for i, item := range items {
+		err = json.Unmarshal(item, values[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func loadKindFixture(t *testing.T, name string) (json.RawMessage, *DataTransaction) {
+	fixtures := map[string]json.RawMessage{}
+	loadFixture(t, "transaction_kinds.json", &fixtures)
+	fixture, ok := fixtures[name]
+	if !ok {
+		t.Fatalf("missing fixture %v", name)
+	}
+	// raw json is written back compact
+	buffer := &bytes.Buffer{}
+	err := json.Compact(buffer, fixture)
+	if err != nil {
+		t.Fatalf("%v: %v", name, err)
+	}
+	data := json.RawMessage(buffer.Bytes())
+	tx := &DataTransaction{}
+	err = json.Unmarshal(data, tx)
+	if err != nil {
+		t.Fatalf("%v: %v", name, err)
+	}
+	// the json written back decodes to the same transaction
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("%v: %v", name, err)
+	}
+	decoded := &DataTransaction{}
+	err = json.Unmarshal(encoded, decoded)
+	if err != nil {
+		t.Fatalf("%v: %v", name, err)
+	}
+	if !reflect.DeepEqual(tx, decoded) {
+		t.Fatalf("%v: roundtrip %+v, expect %+v", name, decoded, tx)
+	}
+	return data, tx
+}
+
+func TestDataTransaction_Programmable(t *testing.T) {
+	_, tx := loadKindFixture(t, "programmable")
+	if !tx.IsProgrammable() || len(tx.Inputs) != 5 {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+	var amount string
+	if err := tx.Inputs[0].PureValue(&amount); err != nil || amount != "1000000" {
+		t.Fatalf("unexpected pure value: %v %v", amount, err)
+	}
+	if shared := tx.Inputs[2]; !shared.IsObject() || shared.ObjectType != SharedObjectInput || shared.InitialSharedVersion != "1" || shared.Mutable {
+		t.Fatalf("unexpected shared input: %+v", shared)
+	}
+	if err := tx.Inputs[1].PureValue(&amount); err == nil {
+		t.Fatalf("expect error for the pure value of an object")
+	}
+
+	var kinds []CommandKind
+	for i := range tx.Transactions {
+		kinds = append(kinds, tx.Transactions[i].Kind())
+	}
+	expectKinds := []CommandKind{SplitCoinsCommandKind, TransferObjectsCommandKind, MergeCoinsCommandKind, MoveCallCommandKind, MoveCallCommandKind,
+		MakeMoveVecCommandKind, MakeMoveVecCommandKind, PublishCommandKind, MoveCallCommandKind, UpgradeCommandKind}
+	if !reflect.DeepEqual(kinds, expectKinds) {
+		t.Fatalf("commands %v, expect %v", kinds, expectKinds)
+	}
+
+	commands := tx.Transactions
+	if split := commands[0].SplitCoins; split.Coin.Kind != GasCoinArgumentKind || split.Amounts[0].String() != "Input(0)" {
+		t.Fatalf("unexpected split: %+v", split)
+	}
+	if transfer := commands[1].TransferObjects; transfer.Objects[0].String() != "NestedResult(0,0)" || transfer.Address.String() != "Input(3)" {
+		t.Fatalf("unexpected transfer: %+v", transfer)
+	}
+	if merge := commands[2].MergeCoins; merge.Destination.String() != "GasCoin" || merge.Sources[0].String() != "Input(1)" {
+		t.Fatalf("unexpected merge: %+v", merge)
+	}
+	if moveCall := commands[4].MoveCall; moveCall.Target() != "0x0000000000000000000000000000000000000000000000000000000000000002::coin::value" ||
+		!reflect.DeepEqual(moveCall.TypeArguments, []string{"0x2::sui::SUI"}) || moveCall.Arguments[0].Kind != GasCoinArgumentKind {
+		t.Fatalf("unexpected move call: %+v", moveCall)
+	}
+	if moveCalls := tx.MoveCalls(); len(moveCalls) != 3 || moveCalls[0].Function != "timestamp_ms" {
+		t.Fatalf("unexpected move calls: %+v", moveCalls)
+	}
+	if vec := commands[5].MakeMoveVec; vec.Type == nil || *vec.Type != "u64" || vec.Elements[0].String() != "Result(4)" {
+		t.Fatalf("unexpected make move vec: %+v", vec)
+	}
+	if vec := commands[6].MakeMoveVec; vec.Type != nil {
+		t.Fatalf("unexpected make move vec type: %v", *vec.Type)
+	}
+	if publish := commands[7].Publish; len(publish.Dependencies) != 2 {
+		t.Fatalf("unexpected publish: %+v", publish)
+	}
+	if upgrade := commands[9].Upgrade; upgrade.Package != "0xf76fc0621825cf4c8d96255d6ced7a7250cbb82014565230f323bb61f4f387b9" ||
+		len(upgrade.Dependencies) != 2 || upgrade.Ticket.String() != "Result(8)" {
+		t.Fatalf("unexpected upgrade: %+v", upgrade)
+	}
+}
+
+func TestDataTransaction_System(t *testing.T) {
+	_, tx := loadKindFixture(t, "consensusCommitPrologueV3")
+	prologue := tx.ConsensusCommitPrologue
+	if tx.Kind != ConsensusCommitPrologueKind || prologue.Version != "ConsensusCommitPrologueV3" || prologue.Round != "2387211" ||
+		prologue.ConsensusCommitDigest != "DzHPyhyBKyqzutgvwUuAt4o4hYyvMVwEgc93agApd8pH" || len(prologue.ConsensusDeterminedVersionAssignments) == 0 {
+		t.Fatalf("unexpected prologue: %+v", prologue)
+	}
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Contains(encoded, []byte(`"kind":"ConsensusCommitPrologueV3"`)) {
+		t.Fatalf("prologue version lost: %v", string(encoded))
+	}
+
+	_, tx = loadKindFixture(t, "changeEpoch")
+	if tx.Kind != ChangeEpochKind || tx.ChangeEpoch.Epoch != "513" || tx.ChangeEpoch.StorageRebate != "1013012448912" {
+		t.Fatalf("unexpected change epoch: %+v", tx.ChangeEpoch)
+	}
+
+	_, tx = loadKindFixture(t, "endOfEpoch")
+	if tx.Kind != EndOfEpochTransactionKind || len(tx.EndOfEpochTransaction.Transactions) != 2 {
+		t.Fatalf("unexpected end of epoch: %+v", tx.EndOfEpochTransaction)
+	}
+}
+
+func TestDataTransaction_Unknown(t *testing.T) {
+	data, tx := loadKindFixture(t, "unknown")
+	if tx.Kind != "ProgrammableSystemTransaction" || tx.IsProgrammable() || tx.Raw == nil {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		panic(err)
+	}
+	if string(encoded) != string(data) {
+		t.Fatalf("unknown kind %v, expect %v", string(encoded), string(data))
+	}
+	if _, err := json.Marshal(DataTransaction{Kind: "ProgrammableSystemTransaction"}); err == nil {
+		t.Fatalf("expect error for unknown kind without raw json")
+	}
+}
+
+func TestArgument_JSON(t *testing.T) {
+	cases := []struct {
+		data   string
+		expect Argument
+	}{
+		{`"GasCoin"`, GasCoinArgument()},
+		{`{"Input":3}`, InputArgument(3)},
+		{`{"Result":1}`, ResultArgument(1)},
+		{`{"NestedResult":[1,2]}`, NestedResultArgument(1, 2)},
+		{`"Sender"`, Argument{Kind: "Sender", Raw: json.RawMessage(`"Sender"`)}},
+		{`{"Receiving":[1,0]}`, Argument{Kind: "Receiving", Raw: json.RawMessage(`{"Receiving":[1,0]}`)}},
+	}
+	for _, c := range cases {
+		var argument Argument
+		err := json.Unmarshal([]byte(c.data), &argument)
+		if err != nil {
+			t.Fatalf("%v: %v", c.data, err)
+		}
+		if !reflect.DeepEqual(argument, c.expect) {
+			t.Fatalf("%v: %+v, expect %+v", c.data, argument, c.expect)
+		}
+		data, err := json.Marshal(argument)
+		if err != nil {
+			t.Fatalf("%v: %v", c.data, err)
+		}
+		if string(data) != c.data {
+			t.Fatalf("roundtrip %v, expect %v", string(data), c.data)
+		}
+	}
+	for _, invalid := range []string{`{"NestedResult":[1]}`, `{"Input":1,"Result":2}`, `[1]`} {
+		var argument Argument
+		if err := json.Unmarshal([]byte(invalid), &argument); err == nil {
+			t.Fatalf("expect error for %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func loadFixture(t *testing.T, name string, value interface{}) {
+	data, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		t.Fatalf("decode %v: %v", name, err)
+	}
+}
+
+func TestTransactionBlock_Effects(t *testing.T) {
+	tb := &TransactionBlock{}
+	loadFixture(t, "transaction_block.json", tb)
+
+	effects := tb.Effects
+	expectCreated := []string{
+		"0xf76fc0621825cf4c8d96255d6ced7a7250cbb82014565230f323bb61f4f387b9",
+		"0x015bb71d8fee4a0955eabfe87057f2d053a134c4eaa2fc307516eaa2dac56bc4",
+		"0xbd49f21a93f0628429378663ef2fe800526c1c5a9f6fd484555cefe0bb2e7412",
+		"0xdd626f23de972c54356deb7f62fca78832fcb131b262ae0b40a87c5f16193bb1",
+	}
+	if created := effects.CreatedObjectIDs(); !reflect.DeepEqual(created, expectCreated) {
+		t.Fatalf("created %v, expect %v", created, expectCreated)
+	}
+	if !effects.Created[0].Owner.IsImmutable() || effects.Created[1].Owner.Address() != "0xa2909dd355aeaffd520847a35da1ef529c4cebd348bb4d69f00f32315118106e" {
+		t.Fatalf("unexpected created owners: %v %v", effects.Created[0].Owner, effects.Created[1].Owner)
+	}
+	expectMutated := []string{"0xc8395ecc3d31845032b541cb0bbaad82cb32434187b3eb465466473c10a28d8c"}
+	if mutated := effects.MutatedObjectIDs(); !reflect.DeepEqual(mutated, expectMutated) {
+		t.Fatalf("mutated %v, expect %v", mutated, expectMutated)
+	}
+	expectDeleted := []string{"0xd9b73054448d84cd282b501b31b509164c4ac3e6568d9323e740be19a35faff2"}
+	if deleted := effects.DeletedObjectIDs(); !reflect.DeepEqual(deleted, expectDeleted) {
+		t.Fatalf("deleted %v, expect %v", deleted, expectDeleted)
+	}
+	if version, ok := effects.SharedObjectVersion("0x6"); !ok || version != 33456789 {
+		t.Fatalf("unexpected clock version: %v %v", version, ok)
+	}
+	if _, ok := effects.SharedObjectVersion("0x5"); ok {
+		t.Fatalf("0x5 is not a shared input")
+	}
+	if effects.GasObject.Reference.Version != 42 || effects.Status.Status != TxSuccess {
+		t.Fatalf("unexpected effects: %+v", effects)
+	}
+}
+
+func TestTransactionBlock_CreatedObjectsOfType(t *testing.T) {
+	tb := &TransactionBlock{}
+	loadFixture(t, "transaction_block.json", tb)
+
+	packageId, ok := tb.PublishedPackageID()
+	if !ok || packageId != "0xf76fc0621825cf4c8d96255d6ced7a7250cbb82014565230f323bb61f4f387b9" {
+		t.Fatalf("unexpected package: %v %v", packageId, ok)
+	}
+	cases := []struct {
+		objectType string
+		expect     []string
+	}{
+		{"0x2::package::UpgradeCap", []string{"0x015bb71d8fee4a0955eabfe87057f2d053a134c4eaa2fc307516eaa2dac56bc4"}},
+		// short and long addresses match, type parameters may be omitted
+		{"0x0000000000000000000000000000000000000000000000000000000000000002::package::UpgradeCap", []string{"0x015bb71d8fee4a0955eabfe87057f2d053a134c4eaa2fc307516eaa2dac56bc4"}},
+		{"0x2::coin::TreasuryCap", []string{"0xbd49f21a93f0628429378663ef2fe800526c1c5a9f6fd484555cefe0bb2e7412"}},
+		{"0x2::coin::CoinMetadata<0xf76fc0621825cf4c8d96255d6ced7a7250cbb82014565230f323bb61f4f387b9::token::TOKEN>", []string{"0xdd626f23de972c54356deb7f62fca78832fcb131b262ae0b40a87c5f16193bb1"}},
+		{"0x2::coin::CoinMetadata<0x2::sui::SUI>", nil},
+		// the gas coin is mutated, not created
+		{"0x2::coin::Coin", nil},
+	}
+	for _, c := range cases {
+		var objectIds []string
+		for _, item := range tb.CreatedObjectsOfType(c.objectType) {
+			objectIds = append(objectIds, item.ObjectID)
+		}
+		if !reflect.DeepEqual(objectIds, c.expect) {
+			t.Fatalf("%v: %v, expect %v", c.objectType, objectIds, c.expect)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"github.com/ltp456/go-sui-sdk/bcs"
+	"strings"
+)
+
+// TypeTag variants in bcs order
+const (
+	typeTagBool = iota
+	typeTagU8
+	typeTagU64
+	typeTagU128
+	typeTagAddress
+	typeTagSigner
+	typeTagVector
+	typeTagStruct
+	typeTagU16
+	typeTagU32
+	typeTagU256
+)
+
+var primitiveTypeTags = map[string]uint64{
+	"bool":    typeTagBool,
+	"u8":      typeTagU8,
+	"u16":     typeTagU16,
+	"u32":     typeTagU32,
+	"u64":     typeTagU64,
+	"u128":    typeTagU128,
+	"u256":    typeTagU256,
+	"address": typeTagAddress,
+	"signer":  typeTagSigner,
+}
+
+// TypeTag is a move type like "u64", "vector<u8>" or "0x2::coin::Coin<0x2::sui::SUI>", encoded as TypeTag in bcs
+type TypeTag string
+
+func (tt TypeTag) String() string {
+	return string(tt)
+}
+
+func (tt TypeTag) MarshalBCS(e *bcs.Encoder) error {
+	return writeTypeTag(e, strings.TrimSpace(string(tt)))
+}
+
+func (tt *TypeTag) UnmarshalBCS(d *bcs.Decoder) error {
+	moveType, err := readTypeTag(d)
+	if err != nil {
+		return err
+	}
+	*tt = TypeTag(moveType)
+	return nil
+}
+
+func writeTypeTag(e *bcs.Encoder, moveType string) error {
+	if tag, ok := primitiveTypeTags[moveType]; ok {
+		e.WriteUleb128(tag)
+		return nil
+	}
+	if strings.HasPrefix(moveType, "vector<") && strings.HasSuffix(moveType, ">") {
+		e.WriteUleb128(typeTagVector)
+		return writeTypeTag(e, strings.TrimSpace(moveType[len("vector<"):len(moveType)-1]))
+	}
+	e.WriteUleb128(typeTagStruct)
+	return writeStructTag(e, moveType)
+}
+
+func writeStructTag(e *bcs.Encoder, moveType string) error {
+	name, params := moveType, ""
+	if index := strings.Index(moveType, "<"); index >= 0 {
+		if !strings.HasSuffix(moveType, ">") {
+			return fmt.Errorf("invalid move type: %v", moveType)
+		}
+		name, params = moveType[:index], moveType[index+1:len(moveType)-1]
+	}
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid move type: %v", moveType)
+	}
+	err := Address(parts[0]).MarshalBCS(e)
+	if err != nil {
+		return err
+	}
+	e.WriteString(parts[1])
+	e.WriteString(parts[2])
+	typeParams, err := splitTypeParams(params)
+	if err != nil {
+		return fmt.Errorf("invalid move type %v: %v", moveType, err)
+	}
+	e.WriteUleb128(uint64(len(typeParams)))
+	for _, param := range typeParams {
+		err = writeTypeTag(e, param)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitTypeParams splits "A, B<C, D>" on the top level commas
+func splitTypeParams(params string) ([]string, error) {
+	if strings.TrimSpace(params) == "" {
+		return nil, nil
+	}
+	var result []string
+	depth, start := 0, 0
+	for i, c := range params {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced type parameters")
+			}
+		case ',':
+			if depth == 0 {
+				result = append(result, strings.TrimSpace(params[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced type parameters")
+	}
+	return append(result, strings.TrimSpace(params[start:])), nil
+}
+
+func readTypeTag(d *bcs.Decoder) (string, error) {
+	tag, err := d.ReadUleb128()
+	if err != nil {
+		return "", err
+	}
+	for name, value := range primitiveTypeTags {
+		if value == tag {
+			return name, nil
+		}
+	}
+	switch tag {
+	case typeTagVector:
+		element, err := readTypeTag(d)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("vector<%v>", element), nil
+	case typeTagStruct:
+		var address Address
+		err = address.UnmarshalBCS(d)
+		if err != nil {
+			return "", err
+		}
+		module, err := d.ReadString()
+		if err != nil {
+			return "", err
+		}
+		name, err := d.ReadString()
+		if err != nil {
+			return "", err
+		}
+		count, err := d.ReadLength()
+		if err != nil {
+			return "", err
+		}
+		moveType := fmt.Sprintf("%v::%v::%v", address, module, name)
+		if count == 0 {
+			return moveType, nil
+		}
+		params := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			param, err := readTypeTag(d)
+			if err != nil {
+				return "", err
+			}
+			params = append(params, param)
+		}
+		return fmt.Sprintf("%v<%v>", moveType, strings.Join(params, ", ")), nil
+	default:
+		return "", fmt.Errorf("unknown type tag: %v", tag)
+	}
+}